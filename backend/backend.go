@@ -15,6 +15,11 @@
 // Package backend implements Zero Knowledge Proof systems: it consumes circuit compiled with gnark/frontend.
 package backend
 
+import "errors"
+
+// ErrUnsatisfiedConstraint is wrapped by the errors reporting a constraint which doesn't hold
+var ErrUnsatisfiedConstraint = errors.New("constraint is not satisfied")
+
 // ID represent a unique ID for a proving scheme
 type ID uint16
 
@@ -23,3 +28,14 @@ const (
 	GROTH16
 	PLONK
 )
+
+func (id ID) String() string {
+	switch id {
+	case GROTH16:
+		return "groth16"
+	case PLONK:
+		return "plonk"
+	default:
+		return "unknown"
+	}
+}
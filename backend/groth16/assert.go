@@ -20,6 +20,8 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
 	backend_bls12377 "github.com/consensys/gnark/internal/backend/bls12-377/cs"
 	witness_bls12377 "github.com/consensys/gnark/internal/backend/bls12-377/witness"
@@ -64,6 +66,8 @@ func (assert *Assert) ProverFailed(r1cs frontend.CompiledConstraintSystem, witne
 //
 // 5. Ensure deserialization(serialization) of generated objects is correct
 //
+// 6. With BN254 and BLS12-381, ensures the proof verifies once exported to and imported from snarkjs
+//
 // ensure result vectors a*b=c, and check other properties like random sampling
 func (assert *Assert) ProverSucceeded(r1cs frontend.CompiledConstraintSystem, witness frontend.Circuit) {
 	// setup
@@ -109,6 +113,29 @@ func (assert *Assert) ProverSucceeded(r1cs frontend.CompiledConstraintSystem, wi
 	assert.serializationRawSucceeded(proof, NewProof(r1cs.CurveID()))
 	assert.serializationRawSucceeded(pk, NewProvingKey(r1cs.CurveID()))
 	assert.serializationRawSucceeded(vk, NewVerifyingKey(r1cs.CurveID()))
+
+	// snarkjs
+	if curveID := r1cs.CurveID(); curveID == ecc.BN254 || curveID == ecc.BLS12_381 {
+		assert.snarkJSSucceeded(r1cs, proof, vk, witness)
+	}
+}
+
+// snarkJSSucceeded exports the verifying key, the proof and the public witness in the snarkjs layout,
+// and verifies the proof imported back
+func (assert *Assert) snarkJSSucceeded(r1cs frontend.CompiledConstraintSystem, proof Proof, vk VerifyingKey, publicWitness frontend.Circuit) {
+	var vkJSON, proofJSON, publicJSON, public bytes.Buffer
+	assert.NoError(vk.ExportSnarkJS(&vkJSON), "exporting the verifying key to snarkjs failed")
+	assert.NoError(proof.ExportSnarkJS(&proofJSON), "exporting the proof to snarkjs failed")
+	_, err := witness.WritePublicSnarkJSTo(&publicJSON, r1cs.CurveID(), publicWitness)
+	assert.NoError(err, "exporting the public witness to snarkjs failed")
+
+	_vk, _proof := NewVerifyingKey(r1cs.CurveID()), NewProof(r1cs.CurveID())
+	assert.NoError(_vk.ImportSnarkJS(&vkJSON), "importing the verifying key from snarkjs failed")
+	assert.NoError(_proof.ImportSnarkJS(&proofJSON), "importing the proof from snarkjs failed")
+	_, err = witness.WritePublicFromSnarkJS(&public, r1cs, &publicJSON)
+	assert.NoError(err, "importing the public witness from snarkjs failed")
+
+	assert.NoError(ReadAndVerify(_proof, _vk, &public), "verifying the proof imported from snarkjs failed")
 }
 
 func (assert *Assert) serializationSucceeded(from io.WriterTo, to io.ReaderFrom) {
@@ -46,10 +46,15 @@ import (
 // Proof represents a Groth16 proof generated by groth16.Prove
 //
 // it's underlying implementation is curve specific (see gnark/internal/backend)
+//
+// ExportSnarkJS and ImportSnarkJS encode the proof in the layout of the proof.json file of snarkjs,
+// they are implemented for BN254 and BLS12-381 and will return an error with other curves
 type Proof interface {
 	gnarkio.WriterRawTo
 	io.WriterTo
 	io.ReaderFrom
+	ExportSnarkJS(w io.Writer) error
+	ImportSnarkJS(r io.Reader) error
 }
 
 // ProvingKey represents a Groth16 ProvingKey
@@ -67,6 +72,10 @@ type ProvingKey interface {
 // it's underlying implementation is strongly typed with the curve (see gnark/internal/backend)
 //
 // ExportSolidity is implemented for BN254 and will return an error with other curves
+//
+// ExportSnarkJS and ImportSnarkJS encode the key in the layout of the verification_key.json file of snarkjs,
+// they are implemented for BN254 and BLS12-381 and will return an error with other curves. The public witness
+// is encoded as the public.json file of snarkjs with package backend/witness (see witness.WritePublicSnarkJSTo)
 type VerifyingKey interface {
 	gnarkio.WriterRawTo
 	io.WriterTo
@@ -74,6 +83,8 @@ type VerifyingKey interface {
 	SizePublicWitness() int // number of elements expected in the public witness
 	IsDifferent(interface{}) bool
 	ExportSolidity(w io.Writer) error
+	ExportSnarkJS(w io.Writer) error
+	ImportSnarkJS(r io.Reader) error
 }
 
 // Verify runs the groth16.Verify algorithm on provided proof with given witness
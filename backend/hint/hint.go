@@ -0,0 +1,155 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hint allows to declare wires whose values are computed outside of the circuit.
+//
+// A hint is a Go function called by the solver (see R1CS.Solve and SparseR1CS.Solve)
+// which computes the value of a wire from the values of its inputs. Since no constraint
+// is attached to the computation, the circuit developer must constrain the output of a hint.
+//
+// Hints are identified by an ID derived from the function name; the ID is what is stored
+// in a compiled constraint system. frontend.ConstraintSystem.NewHint registers the hint automatically,
+// but when a constraint system is deserialized in another process, the hint functions it uses
+// must be registered (see Register) before solving it.
+package hint
+
+import (
+	"errors"
+	"hash/fnv"
+	"math/big"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// ID is a unique identifier of a hint function
+type ID uint32
+
+// Function computes result from inputs. inputs are the (regular form) values of the hint inputs
+// and result is reduced modulo the scalar field of curveID by the solver
+type Function func(curveID ecc.ID, inputs []*big.Int, result *big.Int) error
+
+// ErrNotFound is returned by the solvers when a hint function is not registered
+var ErrNotFound = errors.New("hint function not registered")
+
+var (
+	registry  = make(map[ID]Function)
+	registryM sync.RWMutex
+)
+
+// UUID returns the ID of the hint function, derived from its name
+func UUID(f Function) ID {
+	name := runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return ID(h.Sum32())
+}
+
+// Register registers the hint function f and returns its ID
+func Register(f Function) ID {
+	id := UUID(f)
+	registryM.Lock()
+	registry[id] = f
+	registryM.Unlock()
+	return id
+}
+
+// Lookup returns the hint function registered with provided ID
+func Lookup(id ID) (Function, bool) {
+	registryM.RLock()
+	f, ok := registry[id]
+	registryM.RUnlock()
+	return f, ok
+}
+
+func init() {
+	Register(IsZero)
+	Register(IthBit)
+	Register(InvZero)
+	Register(IntDiv)
+	Register(IntMod)
+}
+
+// IsZero sets result to 1 if inputs[0] == 0, 0 otherwise
+func IsZero(curveID ecc.ID, inputs []*big.Int, result *big.Int) error {
+	if len(inputs) != 1 {
+		return errors.New("IsZero expects one input")
+	}
+	if inputs[0].Sign() == 0 {
+		result.SetUint64(1)
+	} else {
+		result.SetUint64(0)
+	}
+	return nil
+}
+
+// IthBit sets result to the inputs[1]-th bit of inputs[0]
+func IthBit(curveID ecc.ID, inputs []*big.Int, result *big.Int) error {
+	if len(inputs) != 2 {
+		return errors.New("IthBit expects two inputs")
+	}
+	if !inputs[1].IsUint64() {
+		return errors.New("IthBit: bit index is too large")
+	}
+	result.SetUint64(uint64(inputs[0].Bit(int(inputs[1].Uint64()))))
+	return nil
+}
+
+// InvZero sets result to the inverse of inputs[0] in the scalar field of curveID, or 0 if inputs[0] == 0
+func InvZero(curveID ecc.ID, inputs []*big.Int, result *big.Int) error {
+	if len(inputs) != 1 {
+		return errors.New("InvZero expects one input")
+	}
+	if inputs[0].Sign() == 0 {
+		result.SetUint64(0)
+		return nil
+	}
+	q := utils.FrModulus(curveID)
+	if q == nil {
+		return errors.New("InvZero: unknown curve")
+	}
+	if result.ModInverse(inputs[0], q) == nil {
+		return errors.New("InvZero: input is not invertible")
+	}
+	return nil
+}
+
+// IntDiv sets result to the quotient of the integer division of inputs[0] by inputs[1], or 0 if inputs[1] == 0
+func IntDiv(curveID ecc.ID, inputs []*big.Int, result *big.Int) error {
+	if len(inputs) != 2 {
+		return errors.New("IntDiv expects two inputs")
+	}
+	if inputs[1].Sign() == 0 {
+		result.SetUint64(0)
+		return nil
+	}
+	result.Quo(inputs[0], inputs[1])
+	return nil
+}
+
+// IntMod sets result to the remainder of the integer division of inputs[0] by inputs[1], or inputs[0] if inputs[1] == 0
+func IntMod(curveID ecc.ID, inputs []*big.Int, result *big.Int) error {
+	if len(inputs) != 2 {
+		return errors.New("IntMod expects two inputs")
+	}
+	if inputs[1].Sign() == 0 {
+		result.Set(inputs[0])
+		return nil
+	}
+	result.Rem(inputs[0], inputs[1])
+	return nil
+}
@@ -15,6 +15,8 @@
 package plonk
 
 import (
+	"bytes"
+	"io"
 	"testing"
 
 	backend_bls12377 "github.com/consensys/gnark/internal/backend/bls12-377/cs"
@@ -27,6 +29,7 @@ import (
 	witness_bls12381 "github.com/consensys/gnark/internal/backend/bls12-381/witness"
 	witness_bn254 "github.com/consensys/gnark/internal/backend/bn254/witness"
 	witness_bw6761 "github.com/consensys/gnark/internal/backend/bw6-761/witness"
+	gnarkio "github.com/consensys/gnark/io"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,6 +60,35 @@ func (assert *Assert) ProverSucceeded(sparseR1cs frontend.CompiledConstraintSyst
 	err = Verify(proof, publicData, witness)
 	assert.NoError(err, "Verifying correct proof with correct witness should not output an error")
 
+	// serialization
+	curveID := sparseR1cs.CurveID()
+	polynomialCommitment := newDummyCommitment(curveID)
+	assert.serializationSucceeded(proof, NewProof(curveID, polynomialCommitment))
+	assert.serializationSucceeded(publicData, NewPublicData(curveID, polynomialCommitment))
+	assert.serializationRawSucceeded(proof, NewProof(curveID, polynomialCommitment))
+	assert.serializationRawSucceeded(publicData, NewPublicData(curveID, polynomialCommitment))
+}
+
+func (assert *Assert) serializationSucceeded(from io.WriterTo, to io.ReaderFrom) {
+	var buf bytes.Buffer
+	written, err := from.WriteTo(&buf)
+	assert.NoError(err, "serializing to buffer failed")
+
+	read, err := to.ReadFrom(&buf)
+	assert.NoError(err, "desererializing from buffer failed")
+
+	assert.EqualValues(written, read, "number of bytes read and written don't match")
+}
+
+func (assert *Assert) serializationRawSucceeded(from gnarkio.WriterRawTo, to io.ReaderFrom) {
+	var buf bytes.Buffer
+	written, err := from.WriteRawTo(&buf)
+	assert.NoError(err, "serializing raw to buffer failed")
+
+	read, err := to.ReadFrom(&buf)
+	assert.NoError(err, "desererializing raw from buffer failed")
+
+	assert.EqualValues(written, read, "number of bytes read and written don't match")
 }
 
 func (assert *Assert) ProverFailed(sparseR1cs frontend.CompiledConstraintSystem, witness frontend.Circuit) {
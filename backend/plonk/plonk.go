@@ -20,8 +20,12 @@
 package plonk
 
 import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/polynomial"
 	"github.com/consensys/gnark/frontend"
+	gnarkio "github.com/consensys/gnark/io"
 
 	mockcommitment_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial/mockcommitment"
 	mockcommitment_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/polynomial/mockcommitment"
@@ -50,12 +54,24 @@ import (
 // 	- polynomials corresponding to the permutations s1,s2,s3 (either raw or committed)
 // 	- the commitment scheme
 // 	- the fft domains
-type PublicData interface{}
+//
+// it's underlying implementation is strongly typed with the curve (see gnark/internal/backend)
+type PublicData interface {
+	gnarkio.WriterRawTo
+	io.WriterTo
+	io.ReaderFrom
+}
 
 // Proof content might vary according to the PLONK version which is chosen.
 //
 // For instance it can be the commitments of L,R,O,H,Z and the opening proofs.
-type Proof interface{}
+//
+// it's underlying implementation is strongly typed with the curve (see gnark/internal/backend)
+type Proof interface {
+	gnarkio.WriterRawTo
+	io.WriterTo
+	io.ReaderFrom
+}
 
 // Setup prepares the public data associated to a circuit + public inputs.
 func Setup(sparseR1cs frontend.CompiledConstraintSystem, polynomialCommitment polynomial.CommitmentScheme, publicWitness frontend.Circuit) (PublicData, error) {
@@ -231,3 +247,53 @@ func Verify(proof Proof, publicData PublicData, publicWitness frontend.Circuit)
 		panic("unrecognized proof type")
 	}
 }
+
+// NewPublicData instantiates a curve-typed PublicData, which may be decoded with ReadFrom.
+// The commitment scheme must be of the type used at setup, it is decoded in place.
+func NewPublicData(curveID ecc.ID, polynomialCommitment polynomial.CommitmentScheme) PublicData {
+	switch curveID {
+	case ecc.BN254:
+		return &plonkbn254.PublicRaw{CommitmentScheme: polynomialCommitment}
+	case ecc.BLS12_381:
+		return &plonkbls12381.PublicRaw{CommitmentScheme: polynomialCommitment}
+	case ecc.BLS12_377:
+		return &plonkbls12377.PublicRaw{CommitmentScheme: polynomialCommitment}
+	case ecc.BW6_761:
+		return &plonkbw6761.PublicRaw{CommitmentScheme: polynomialCommitment}
+	default:
+		panic("not implemented")
+	}
+}
+
+// NewProof instantiates a curve-typed Proof, which may be decoded with ReadFrom.
+// The commitment scheme must be the one the proof was generated with.
+func NewProof(curveID ecc.ID, polynomialCommitment polynomial.CommitmentScheme) Proof {
+	switch curveID {
+	case ecc.BN254:
+		return plonkbn254.NewProofRaw(polynomialCommitment)
+	case ecc.BLS12_381:
+		return plonkbls12381.NewProofRaw(polynomialCommitment)
+	case ecc.BLS12_377:
+		return plonkbls12377.NewProofRaw(polynomialCommitment)
+	case ecc.BW6_761:
+		return plonkbw6761.NewProofRaw(polynomialCommitment)
+	default:
+		panic("not implemented")
+	}
+}
+
+// newDummyCommitment returns the dummy polynomial commitment scheme of the curve (see SetupDummyCommitment)
+func newDummyCommitment(curveID ecc.ID) polynomial.CommitmentScheme {
+	switch curveID {
+	case ecc.BN254:
+		return &mockcommitment_bn254.Scheme{}
+	case ecc.BLS12_381:
+		return &mockcommitment_bls12381.Scheme{}
+	case ecc.BLS12_377:
+		return &mockcommitment_bls12377.Scheme{}
+	case ecc.BW6_761:
+		return &mockcommitment_bw6761.Scheme{}
+	default:
+		panic("not implemented")
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package solver computes the values of all the wires of a compiled constraint system
+// (public, secret and internal), without generating a proof.
+//
+// It is meant to inspect the intermediate values of a circuit, to debug it, or to cache a solution.
+package solver
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/bigint"
+)
+
+// Solution holds the values of all the wires of a constraint system
+//
+// The wires are ordered as in the constraint system: [public | secret | internal]. For a R1CS
+// (groth16), the first public wire is the constant wire ONE_WIRE.
+type Solution struct {
+	Values []big.Int // values of the wires, in regular form
+	Names  []string  // names of the public and secret wires (see frontend.CompiledConstraintSystem.PublicInputNames), "" for the internal wires
+
+	NbPublic, NbSecret, NbInternal int
+}
+
+// Public returns the values of the public wires
+func (s *Solution) Public() []big.Int {
+	return s.Values[:s.NbPublic]
+}
+
+// Secret returns the values of the secret wires
+func (s *Solution) Secret() []big.Int {
+	return s.Values[s.NbPublic : s.NbPublic+s.NbSecret]
+}
+
+// Internal returns the values of the internal wires
+func (s *Solution) Internal() []big.Int {
+	return s.Values[s.NbPublic+s.NbSecret:]
+}
+
+// Lookup returns a copy of the value of the public or secret wire with the given name
+func (s *Solution) Lookup(name string) (*big.Int, bool) {
+	for i := 0; i < s.NbPublic+s.NbSecret; i++ {
+		if s.Names[i] == name {
+			return new(big.Int).Set(&s.Values[i]), true
+		}
+	}
+	return nil, false
+}
+
+// Option tunes the solver (see Solve)
+type Option func(config *solverConfig)
+
+type solverConfig struct {
+	diagnostic bool
+}
+
+// Diagnostic sets the solver in diagnostic mode: the solver doesn't stop at the first constraint
+// which doesn't hold, and Solve returns the solution along with an *UnsatisfiedConstraintsError
+// listing all the constraints which don't hold.
+//
+// Once a constraint computing a wire doesn't hold (ex: division by 0), the values of the wires depending
+// on it are meaningless: the first constraints of the list are usually the most relevant.
+func Diagnostic() Option {
+	return func(config *solverConfig) {
+		config.diagnostic = true
+	}
+}
+
+// Solve solves the constraint system on the assignment (a full witness) and returns the values
+// of all the wires.
+//
+// The public outputs (gnark:",output") may be left unassigned: they are computed by the solver.
+// Solve returns an error if a constraint is not satisfied (see Diagnostic).
+func Solve(ccs frontend.CompiledConstraintSystem, assignment frontend.Circuit, opts ...Option) (*Solution, error) {
+	var config solverConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	_ccs, ok := ccs.(bigint.ConstraintSystem)
+	if !ok {
+		return nil, fmt.Errorf("%T can't be solved, compile it for a curve", ccs)
+	}
+	values, unsatisfied, err := bigint.Solve(_ccs, assignment, config.diagnostic)
+	if err != nil {
+		return nil, err
+	}
+
+	// the R1CS have a ONE_WIRE, the SparseR1CS don't
+	_, oneWire := ccs.(bigint.R1CS)
+	sparse := !oneWire
+
+	res := &Solution{Values: values}
+	res.NbInternal, res.NbSecret, res.NbPublic = ccs.GetNbVariables()
+
+	// the names of the public and secret wires are recorded in the constraint system
+	res.Names = make([]string, len(values))
+	if oneWire {
+		res.Names[0] = "ONE_WIRE"
+		copy(res.Names[1:], ccs.PublicInputNames())
+	} else {
+		copy(res.Names, ccs.PublicInputNames())
+	}
+	copy(res.Names[res.NbPublic:], ccs.SecretInputNames())
+
+	if len(unsatisfied) == 0 {
+		return res, nil
+	}
+
+	// diagnostic mode: the wires of the constraints are named after the solution
+	uErr := &UnsatisfiedConstraintsError{Constraints: make([]UnsatisfiedConstraint, len(unsatisfied))}
+	for i := 0; i < len(unsatisfied); i++ {
+		u := &unsatisfied[i]
+		c := UnsatisfiedConstraint{
+			Assertion: u.Assertion,
+			ID:        u.ID,
+			L:         u.L,
+			R:         u.R,
+			M:         u.M,
+			O:         u.O,
+			K:         u.K,
+			DebugInfo: u.DebugInfo,
+			Wires:     make([]Wire, len(u.Wires)),
+			sparse:    sparse,
+		}
+		for j, id := range u.Wires {
+			c.Wires[j] = Wire{ID: id, Name: res.Names[id], Value: res.Values[id]}
+		}
+		uErr.Constraints[i] = c
+	}
+	return res, uErr
+}
+
+// Wire is a wire of a constraint, with its value
+type Wire struct {
+	ID    int    // ID of the wire in the solution
+	Name  string // name of the wire, "" for an internal wire
+	Value big.Int
+}
+
+func (w Wire) String() string {
+	if w.Name == "" {
+		return fmt.Sprintf("wire%d = %s", w.ID, w.Value.String())
+	}
+	return w.Name + " = " + w.Value.String()
+}
+
+// UnsatisfiedConstraint describes a constraint which doesn't hold (see Diagnostic)
+//
+// For a R1CS (groth16), L * R != O. For a SparseR1CS (PLONK), L + R + M + O + K != 0,
+// where L = qL.xa, R = qR.xb, M = qM.xa.xb, O = qO.xc and K = qK.
+type UnsatisfiedConstraint struct {
+	Assertion bool // true if the constraint is an assertion, false if it computes a wire
+	ID        int  // index of the constraint in the constraint system (among the assertions, for a SparseR1CS assertion)
+
+	L, R, M, O, K big.Int
+
+	DebugInfo string // debug info of the assertion (the call stack), with the values of the wires
+	Wires     []Wire // wires of the constraint
+
+	sparse bool
+}
+
+func (c *UnsatisfiedConstraint) String() string {
+	var sbb strings.Builder
+	if c.Assertion {
+		sbb.WriteString(fmt.Sprintf("assertion %d: ", c.ID))
+	} else {
+		sbb.WriteString(fmt.Sprintf("constraint %d: ", c.ID))
+	}
+	if c.sparse {
+		sbb.WriteString(fmt.Sprintf("%s + %s + %s + %s + %s != 0", c.L.String(), c.R.String(), c.M.String(), c.O.String(), c.K.String()))
+	} else {
+		sbb.WriteString(fmt.Sprintf("%s * %s != %s", c.L.String(), c.R.String(), c.O.String()))
+	}
+	if len(c.Wires) > 0 {
+		wires := make([]string, len(c.Wires))
+		for i := 0; i < len(c.Wires); i++ {
+			wires[i] = c.Wires[i].String()
+		}
+		sbb.WriteString(" (" + strings.Join(wires, ", ") + ")")
+	}
+	if c.DebugInfo != "" {
+		sbb.WriteString("\n" + c.DebugInfo)
+	}
+	return sbb.String()
+}
+
+// UnsatisfiedConstraintsError lists all the constraints which don't hold (see Diagnostic)
+type UnsatisfiedConstraintsError struct {
+	Constraints []UnsatisfiedConstraint
+}
+
+func (e *UnsatisfiedConstraintsError) Error() string {
+	var sbb strings.Builder
+	sbb.WriteString(fmt.Sprintf("%s: %d unsatisfied constraint(s)", backend.ErrUnsatisfiedConstraint.Error(), len(e.Constraints)))
+	for i := 0; i < len(e.Constraints); i++ {
+		sbb.WriteString("\n\n" + e.Constraints[i].String())
+	}
+	return sbb.String()
+}
+
+// Unwrap returns backend.ErrUnsatisfiedConstraint
+func (e *UnsatisfiedConstraintsError) Unwrap() error {
+	return backend.ErrUnsatisfiedConstraint
+}
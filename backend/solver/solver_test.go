@@ -0,0 +1,149 @@
+package solver
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+)
+
+type cubicCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+	Z frontend.Variable `gnark:",output"`
+}
+
+func (circuit *cubicCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	x3 := cs.Mul(circuit.X, circuit.X, circuit.X)
+	cs.AssertIsEqual(circuit.Y, cs.Add(x3, circuit.X, 5))
+	circuit.Z = cs.Mul(x3, 2)
+	return nil
+}
+
+func TestSolve(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+		for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+			var circuit cubicCircuit
+			ccs, err := frontend.Compile(curveID, zkpID, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var assignment cubicCircuit
+			assignment.X.Assign(3)
+			assignment.Y.Assign(35)
+			solution, err := Solve(ccs, &assignment)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nbInternal, nbSecret, nbPublic := ccs.GetNbVariables()
+			if len(solution.Values) != nbInternal+nbSecret+nbPublic || len(solution.Internal()) != nbInternal {
+				t.Fatalf("%s %v: wrong number of wires", curveID, zkpID)
+			}
+			for name, expected := range map[string]int64{"X": 3, "Y": 35, "Z": 54} {
+				v, ok := solution.Lookup(name)
+				if !ok || v.Cmp(big.NewInt(expected)) != 0 {
+					t.Fatalf("%s %v: wrong value for %s", curveID, zkpID, name)
+				}
+			}
+
+			// x^3 is computed by the solver
+			found := false
+			for _, v := range solution.Internal() {
+				if v.Cmp(big.NewInt(27)) == 0 {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("%s %v: intermediate value x^3 not found", curveID, zkpID)
+			}
+
+			var wrong cubicCircuit
+			wrong.X.Assign(3)
+			wrong.Y.Assign(36)
+			if _, err := Solve(ccs, &wrong); !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+				t.Fatalf("%s %v: solving with a wrong assignment should fail, got %v", curveID, zkpID, err)
+			}
+		}
+	}
+
+	// a constraint system which is not tied to a curve can't be solved
+	var circuit cubicCircuit
+	ccs, err := frontend.Compile(ecc.UNKNOWN, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var assignment cubicCircuit
+	assignment.X.Assign(3)
+	assignment.Y.Assign(35)
+	if _, err := Solve(ccs, &assignment); err == nil {
+		t.Fatal("solving a constraint system which is not tied to a curve should fail")
+	}
+}
+
+type diagnosticCircuit struct {
+	X, Y frontend.Variable
+	Z    frontend.Variable `gnark:",public"`
+}
+
+func (circuit *diagnosticCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	cs.AssertIsEqual(cs.Mul(circuit.X, circuit.X), circuit.Z)
+	cs.AssertIsBoolean(circuit.Y)
+	cs.AssertIsEqual(circuit.X, circuit.Y)
+	return nil
+}
+
+func TestDiagnostic(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+		for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+			var circuit diagnosticCircuit
+			ccs, err := frontend.Compile(curveID, zkpID, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the first and the third assertions don't hold
+			var assignment diagnosticCircuit
+			assignment.X.Assign(3)
+			assignment.Y.Assign(1)
+			assignment.Z.Assign(10)
+
+			if _, err := Solve(ccs, &assignment); err == nil {
+				t.Fatalf("%s %v: solving should fail", curveID, zkpID)
+			}
+
+			solution, err := Solve(ccs, &assignment, Diagnostic())
+			var uErr *UnsatisfiedConstraintsError
+			if !errors.As(err, &uErr) || !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+				t.Fatalf("%s %v: expected an UnsatisfiedConstraintsError, got %v", curveID, zkpID, err)
+			}
+			if solution == nil {
+				t.Fatalf("%s %v: the solution should be returned in diagnostic mode", curveID, zkpID)
+			}
+			if len(uErr.Constraints) != 2 {
+				t.Fatalf("%s %v: expected 2 unsatisfied constraints, got %d", curveID, zkpID, len(uErr.Constraints))
+			}
+
+			for _, c := range uErr.Constraints {
+				if !c.Assertion || !strings.Contains(c.DebugInfo, "solver_test.go") {
+					t.Fatalf("%s %v: the debug info should contain the call site of the assertion", curveID, zkpID)
+				}
+			}
+			if first := uErr.Constraints[0]; zkpID == backend.GROTH16 && (first.L.Cmp(big.NewInt(9)) != 0 || first.O.Cmp(big.NewInt(10)) != 0) {
+				t.Fatalf("%s %v: wrong evaluation of the first assertion: %s", curveID, zkpID, first.String())
+			}
+			names := make(map[string]bool)
+			for _, w := range uErr.Constraints[1].Wires {
+				names[w.Name] = true
+			}
+			if !names["X"] || !names["Y"] {
+				t.Fatalf("%s %v: the wires of the assertion should be named", curveID, zkpID)
+			}
+		}
+	}
+}
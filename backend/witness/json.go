@@ -0,0 +1,153 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package witness
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"reflect"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/bigint"
+	"github.com/consensys/gnark/internal/backend/compiled"
+	"github.com/consensys/gnark/internal/parser"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// JSONOption configures the JSON encoding of a witness
+type JSONOption func(*jsonConfig)
+
+type jsonConfig struct {
+	hex bool
+}
+
+// WithHex encodes the field elements as hexadecimal strings ("0x" prefix) instead of decimal strings
+func WithHex() JSONOption {
+	return func(config *jsonConfig) {
+		config.hex = true
+	}
+}
+
+// WriteFullJSONTo encodes the full witness as a JSON object, and writes it on the provided writer.
+//
+// The object maps the name of each input (see parser.Visit) to its value, a field element encoded
+// as a decimal (or hexadecimal, see WithHex) string. The inputs are in the order of the binary witness:
+//
+//	{
+//		"Y": "35",
+//		"X": "3",
+//		"Z": "2"
+//	}
+//
+// WriteFullFromJSON converts it back to the binary format.
+func WriteFullJSONTo(w io.Writer, curveID ecc.ID, witness frontend.Circuit, opts ...JSONOption) (int64, error) {
+	var buf bytes.Buffer
+	if _, err := WriteFullTo(&buf, curveID, witness); err != nil {
+		return 0, err
+	}
+	publicNames, secretNames := inputNames(witness)
+	return writeJSON(w, curveID, append(publicNames, secretNames...), &buf, opts)
+}
+
+// WritePublicJSONTo encodes the public witness as a JSON object (see WriteFullJSONTo), and writes it on the provided writer.
+//
+// WritePublicFromJSON converts it back to the binary format.
+func WritePublicJSONTo(w io.Writer, curveID ecc.ID, publicWitness frontend.Circuit, opts ...JSONOption) (int64, error) {
+	var buf bytes.Buffer
+	if _, err := WritePublicTo(&buf, curveID, publicWitness); err != nil {
+		return 0, err
+	}
+	publicNames, _ := inputNames(publicWitness)
+	return writeJSON(w, curveID, publicNames, &buf, opts)
+}
+
+// FullBinaryToJSON reads a binary full witness of the compiled constraint system from r,
+// and writes it as a JSON object (see WriteFullJSONTo) on the provided writer
+func FullBinaryToJSON(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader, opts ...JSONOption) (int64, error) {
+	if utils.FrModulus(ccs.CurveID()) == nil {
+		return 0, errUnknownCurve
+	}
+	names := ccs.PublicInputNames()
+	names = append(names[:len(names):len(names)], ccs.SecretInputNames()...)
+	return writeJSON(w, ccs.CurveID(), names, r, opts)
+}
+
+// PublicBinaryToJSON reads a binary public witness of the compiled constraint system from r,
+// and writes it as a JSON object (see WriteFullJSONTo) on the provided writer
+func PublicBinaryToJSON(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader, opts ...JSONOption) (int64, error) {
+	if utils.FrModulus(ccs.CurveID()) == nil {
+		return 0, errUnknownCurve
+	}
+	return writeJSON(w, ccs.CurveID(), ccs.PublicInputNames(), r, opts)
+}
+
+// writeJSON reads a binary witness of len(names) elements from r, and writes it as a JSON object
+func writeJSON(w io.Writer, curveID ecc.ID, names []string, r io.Reader, opts []JSONOption) (int64, error) {
+	var config jsonConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	values, err := readValues(r, curveID, len(names))
+	if err != nil {
+		return 0, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i := 0; i < len(names); i++ {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		name, err := json.Marshal(names[i])
+		if err != nil {
+			return 0, err
+		}
+		value := values[i].String()
+		if config.hex {
+			value = "0x" + values[i].Text(16)
+		}
+		buf.WriteString("\n\t")
+		buf.Write(name)
+		buf.WriteString(": \"" + value + "\"")
+	}
+	buf.WriteString("\n}\n")
+	return buf.WriteTo(w)
+}
+
+// inputNames returns the names of the public inputs (including the public outputs)
+// and of the secret inputs of the circuit, in the order of the binary witness
+func inputNames(circuit frontend.Circuit) (publicNames, secretNames []string) {
+	var collectHandler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
+		if visibility == compiled.Secret {
+			secretNames = append(secretNames, name)
+		} else if visibility == compiled.Public || visibility == compiled.Output {
+			publicNames = append(publicNames, name)
+		}
+		return nil
+	}
+	if err := parser.Visit(circuit, "", compiled.Unset, collectHandler, reflect.TypeOf(frontend.Variable{})); err != nil {
+		panic("collect handler doesn't return an error -- this panic should not happen")
+	}
+	return
+}
+
+// readValues decodes a binary witness of expectedSize elements
+func readValues(r io.Reader, curveID ecc.ID, expectedSize int) ([]big.Int, error) {
+	return bigint.EncodingOf(curveID).LimitReadFrom(r, expectedSize)
+}
@@ -0,0 +1,135 @@
+package witness
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestJSON(t *testing.T) {
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(50)
+	w.Key.Assign(-1)
+
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+		var circuit valuesCircuit
+		ccs, err := frontend.Compile(curveID, backend.GROTH16, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var full, public bytes.Buffer
+		if _, err := WriteFullTo(&full, curveID, &w); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := WritePublicTo(&public, curveID, &w); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, opts := range [][]JSONOption{nil, {WithHex()}} {
+			var fullJSON, publicJSON bytes.Buffer
+			if _, err := WriteFullJSONTo(&fullJSON, curveID, &w, opts...); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := WritePublicJSONTo(&publicJSON, curveID, &w, opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			// binary -> JSON
+			var buf bytes.Buffer
+			if _, err := FullBinaryToJSON(&buf, ccs, bytes.NewReader(full.Bytes()), opts...); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != fullJSON.String() {
+				t.Fatalf("%s: full witness: binary and circuit JSON encodings differ:\n%s\n%s", curveID, buf.String(), fullJSON.String())
+			}
+			buf.Reset()
+			if _, err := PublicBinaryToJSON(&buf, ccs, bytes.NewReader(public.Bytes()), opts...); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != publicJSON.String() {
+				t.Fatalf("%s: public witness: binary and circuit JSON encodings differ:\n%s\n%s", curveID, buf.String(), publicJSON.String())
+			}
+
+			// JSON -> binary
+			buf.Reset()
+			if _, err := WriteFullFromJSON(&buf, ccs, &fullJSON); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), full.Bytes()) {
+				t.Fatalf("%s: full witness: JSON round trip failed", curveID)
+			}
+			buf.Reset()
+			if _, err := WritePublicFromJSON(&buf, ccs, &publicJSON); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(buf.Bytes(), public.Bytes()) {
+				t.Fatalf("%s: public witness: JSON round trip failed", curveID)
+			}
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(50)
+	w.Key.Assign(5)
+
+	var buf bytes.Buffer
+	if _, err := WriteFullJSONTo(&buf, ecc.BN254, &w, WithHex()); err != nil {
+		t.Fatal(err)
+	}
+	const expected = `{
+	"Transfers_0_Amount": "0x1",
+	"Transfers_0_Nonce": "0x2",
+	"Transfers_1_Amount": "0x3",
+	"Transfers_1_Nonce": "0x4",
+	"Root": "0x32",
+	"Key": "0x5"
+}
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected JSON encoding:\n%s", buf.String())
+	}
+}
+
+func TestJSONUnknownCurve(t *testing.T) {
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(50)
+	w.Key.Assign(5)
+
+	var full, public bytes.Buffer
+	if _, err := WriteFullTo(&full, ecc.BN254, &w); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WritePublicTo(&public, ecc.BN254, &w); err != nil {
+		t.Fatal(err)
+	}
+
+	var circuit valuesCircuit
+	ccs, err := frontend.Compile(ecc.UNKNOWN, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := FullBinaryToJSON(&bytes.Buffer{}, ccs, &full); err == nil {
+		t.Fatal("decoding the full witness of a constraint system without curve should fail")
+	}
+	if _, err := PublicBinaryToJSON(&bytes.Buffer{}, ccs, &public); err == nil {
+		t.Fatal("decoding the public witness of a constraint system without curve should fail")
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package witness
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// WritePublicFromFull reads a binary full witness of the compiled constraint system from r,
+// and writes the corresponding binary public witness on the provided writer.
+//
+// The number of elements of the full witness must match the number of public and secret
+// inputs of the compiled constraint system (see PublicInputNames and SecretInputNames).
+func WritePublicFromFull(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader) (int64, error) {
+	if utils.FrModulus(ccs.CurveID()) == nil {
+		return 0, errUnknownCurve
+	}
+	nbPublic, nbSecret := len(ccs.PublicInputNames()), len(ccs.SecretInputNames())
+
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, err
+	}
+	if nbElements := binary.BigEndian.Uint32(buf[:]); int(nbElements) != nbPublic+nbSecret {
+		return 0, fmt.Errorf("invalid witness size, got %d, expected %d = %d (public) + %d (secret)", nbElements, nbPublic+nbSecret, nbPublic, nbSecret)
+	}
+
+	// the full witness is decoded to check that it is well formed
+	values, err := readValues(io.MultiReader(bytes.NewReader(buf[:]), r), ccs.CurveID(), nbPublic+nbSecret)
+	if err != nil {
+		return 0, err
+	}
+	return writeValues(w, ccs.CurveID(), values[:nbPublic])
+}
@@ -0,0 +1,82 @@
+package witness
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+)
+
+func TestWritePublicFromFull(t *testing.T) {
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(50)
+	w.Key.Assign(5)
+
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+		for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+			var circuit valuesCircuit
+			ccs, err := frontend.Compile(curveID, zkpID, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var full, expected, public bytes.Buffer
+			if _, err := WriteFullTo(&full, curveID, &w); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := WritePublicTo(&expected, curveID, &w); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := WritePublicFromFull(&public, ccs, bytes.NewReader(full.Bytes())); err != nil {
+				t.Fatalf("%s %v: %v", curveID, zkpID, err)
+			}
+			if !bytes.Equal(public.Bytes(), expected.Bytes()) {
+				t.Fatalf("%s %v: wrong public witness", curveID, zkpID)
+			}
+
+			// the public witness is not a full witness
+			_, err = WritePublicFromFull(&bytes.Buffer{}, ccs, bytes.NewReader(expected.Bytes()))
+			if err == nil || !strings.Contains(err.Error(), "invalid witness size") {
+				t.Fatalf("%s %v: expected an invalid witness size error, got %v", curveID, zkpID, err)
+			}
+
+			// truncated full witness
+			_, err = WritePublicFromFull(&bytes.Buffer{}, ccs, bytes.NewReader(full.Bytes()[:full.Len()-1]))
+			if err == nil {
+				t.Fatalf("%s %v: expected an error on a truncated witness", curveID, zkpID)
+			}
+		}
+	}
+}
+
+func TestWritePublicFromFullUnknownCurve(t *testing.T) {
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(50)
+	w.Key.Assign(5)
+
+	var full bytes.Buffer
+	if _, err := WriteFullTo(&full, ecc.BN254, &w); err != nil {
+		t.Fatal(err)
+	}
+
+	var circuit valuesCircuit
+	ccs, err := frontend.Compile(ecc.UNKNOWN, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WritePublicFromFull(&bytes.Buffer{}, ccs, &full); err == nil {
+		t.Fatal("extracting the public witness of a constraint system without curve should fail")
+	}
+}
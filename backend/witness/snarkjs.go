@@ -0,0 +1,102 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package witness
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// WritePublicSnarkJSTo encodes the public witness in the layout of the public.json file of snarkjs,
+// and writes it on the provided writer.
+//
+// The public signals of snarkjs are a JSON array of the values of the public inputs (decimal strings),
+// in the order of the binary witness:
+//
+//	[
+//	 "35"
+//	]
+//
+// With a Groth16 verifying key and a proof exported to snarkjs (see groth16.VerifyingKey), the public
+// witness is verified by snarkjs groth16 verify. WritePublicFromSnarkJS converts it back to the binary format.
+func WritePublicSnarkJSTo(w io.Writer, curveID ecc.ID, publicWitness frontend.Circuit) (int64, error) {
+	var buf bytes.Buffer
+	if _, err := WritePublicTo(&buf, curveID, publicWitness); err != nil {
+		return 0, err
+	}
+	publicNames, _ := inputNames(publicWitness)
+	return writeSnarkJS(w, curveID, len(publicNames), &buf)
+}
+
+// PublicBinaryToSnarkJS reads a binary public witness of the compiled constraint system from r,
+// and writes it in the layout of the public.json file of snarkjs (see WritePublicSnarkJSTo) on the provided writer
+func PublicBinaryToSnarkJS(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader) (int64, error) {
+	if utils.FrModulus(ccs.CurveID()) == nil {
+		return 0, errUnknownCurve
+	}
+	return writeSnarkJS(w, ccs.CurveID(), len(ccs.PublicInputNames()), r)
+}
+
+// WritePublicFromSnarkJS reads a public.json file of snarkjs (see WritePublicSnarkJSTo), and writes
+// the binary public witness of the compiled constraint system on the provided writer
+func WritePublicFromSnarkJS(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader) (int64, error) {
+	var signals []string
+	if err := json.NewDecoder(r).Decode(&signals); err != nil {
+		return 0, fmt.Errorf("invalid snarkjs public signals: %w", err)
+	}
+	if len(signals) != len(ccs.PublicInputNames()) {
+		return 0, fmt.Errorf("invalid witness size, got %d public signals, expected %d", len(signals), len(ccs.PublicInputNames()))
+	}
+
+	q := utils.FrModulus(ccs.CurveID())
+	if q == nil {
+		return 0, errUnknownCurve
+	}
+	values := make([]big.Int, len(signals))
+	for i, s := range signals {
+		if _, ok := values[i].SetString(s, 10); !ok || values[i].Sign() < 0 || values[i].Cmp(q) >= 0 {
+			return 0, fmt.Errorf("public signal %d: %q is not a field element", i, s)
+		}
+	}
+	return writeValues(w, ccs.CurveID(), values)
+}
+
+// writeSnarkJS reads a binary witness of nbElements from r, and writes it as a JSON array
+// the way snarkjs does (JSON.stringify(publicSignals, null, 1))
+func writeSnarkJS(w io.Writer, curveID ecc.ID, nbElements int, r io.Reader) (int64, error) {
+	values, err := readValues(r, curveID, nbElements)
+	if err != nil {
+		return 0, err
+	}
+	signals := make([]string, len(values))
+	for i := 0; i < len(values); i++ {
+		signals[i] = values[i].String()
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", " ")
+	if err := enc.Encode(signals); err != nil {
+		return 0, err
+	}
+	return buf.WriteTo(w)
+}
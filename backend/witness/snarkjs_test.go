@@ -0,0 +1,110 @@
+package witness
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+func TestSnarkJS(t *testing.T) {
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(-1)
+	w.Key.Assign(5)
+
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		var circuit valuesCircuit
+		ccs, err := frontend.Compile(curveID, backend.GROTH16, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var public, publicJSON bytes.Buffer
+		if _, err := WritePublicTo(&public, curveID, &w); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := WritePublicSnarkJSTo(&publicJSON, curveID, &w); err != nil {
+			t.Fatal(err)
+		}
+
+		// Root = -1 is encoded as q - 1
+		var root big.Int
+		root.Sub(utils.FrModulus(curveID), big.NewInt(1))
+		expected := "[\n \"1\",\n \"2\",\n \"3\",\n \"4\",\n \"" + root.String() + "\"\n]\n"
+		if publicJSON.String() != expected {
+			t.Fatalf("%s: unexpected public signals:\n%s", curveID, publicJSON.String())
+		}
+
+		// binary -> JSON
+		var buf bytes.Buffer
+		if _, err := PublicBinaryToSnarkJS(&buf, ccs, bytes.NewReader(public.Bytes())); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != publicJSON.String() {
+			t.Fatalf("%s: binary and circuit encodings differ:\n%s\n%s", curveID, buf.String(), publicJSON.String())
+		}
+
+		// JSON -> binary
+		buf.Reset()
+		if _, err := WritePublicFromSnarkJS(&buf, ccs, &publicJSON); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf.Bytes(), public.Bytes()) {
+			t.Fatalf("%s: round trip failed", curveID)
+		}
+	}
+}
+
+func TestSnarkJSErrors(t *testing.T) {
+	var circuit valuesCircuit
+	ccs, err := frontend.Compile(ecc.BN254, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, publicJSON := range map[string]string{
+		"not an array":   `{"Root": "1"}`,
+		"missing signal": `["1", "2", "3", "4"]`,
+		"number":         `[1, "2", "3", "4", "5"]`,
+		"hexadecimal":    `["0x1", "2", "3", "4", "5"]`,
+		"negative":       `["-1", "2", "3", "4", "5"]`,
+		"not reduced":    `["1", "2", "3", "4", "` + utils.FrModulus(ecc.BN254).String() + `"]`,
+		"extra signal":   `["1", "2", "3", "4", "5", "6"]`,
+	} {
+		if _, err := WritePublicFromSnarkJS(&bytes.Buffer{}, ccs, strings.NewReader(publicJSON)); err == nil {
+			t.Fatalf("%s: decoding invalid public signals should fail", name)
+		}
+	}
+
+	// the public signals can't be reduced without a scalar field
+	ccs, err = frontend.Compile(ecc.UNKNOWN, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WritePublicFromSnarkJS(&bytes.Buffer{}, ccs, strings.NewReader(`["1", "2", "3", "4", "5"]`)); err == nil {
+		t.Fatal("decoding public signals for a constraint system without curve should fail")
+	}
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(50)
+	w.Key.Assign(5)
+	var public bytes.Buffer
+	if _, err := WritePublicTo(&public, ecc.BN254, &w); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PublicBinaryToSnarkJS(&bytes.Buffer{}, ccs, &public); err == nil {
+		t.Fatal("encoding public signals for a constraint system without curve should fail")
+	}
+}
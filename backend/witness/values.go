@@ -0,0 +1,230 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package witness
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/bigint"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// errUnknownCurve is returned when the witness of a constraint system compiled for ecc.UNKNOWN
+// is encoded or decoded: the size of its elements depends on the scalar field
+var errUnknownCurve = errors.New("the scalar field is unknown, the constraint system must be compiled for a curve")
+
+// WriteFullFromMap encodes the full witness of the compiled constraint system, and writes it on the provided writer.
+//
+// The values of the inputs are keyed by their names (see frontend.CompiledConstraintSystem.PublicInputNames),
+// and may be nested following the structure of the circuit: {"Transfers": [{"Amount": 1}]} and
+// {"Transfers_0_Amount": 1} are equivalent. A value is an integer (int, uint64, big.Int, json.Number, ...)
+// or a string holding a decimal or a hexadecimal ("0x" prefix) integer.
+//
+// All the inputs must be assigned (the public outputs computed by the solver may be set to 0),
+// and the keys which don't match an input are rejected.
+func WriteFullFromMap(w io.Writer, ccs frontend.CompiledConstraintSystem, values map[string]interface{}) (int64, error) {
+	if utils.FrModulus(ccs.CurveID()) == nil {
+		return 0, errUnknownCurve
+	}
+	witness, err := fromMap(ccs, values, false)
+	if err != nil {
+		return 0, err
+	}
+	return writeValues(w, ccs.CurveID(), witness)
+}
+
+// WritePublicFromMap encodes the public witness of the compiled constraint system, and writes it on the provided writer.
+//
+// The values are keyed as in WriteFullFromMap. The values of the secret inputs, if any, are ignored.
+func WritePublicFromMap(w io.Writer, ccs frontend.CompiledConstraintSystem, values map[string]interface{}) (int64, error) {
+	if utils.FrModulus(ccs.CurveID()) == nil {
+		return 0, errUnknownCurve
+	}
+	witness, err := fromMap(ccs, values, true)
+	if err != nil {
+		return 0, err
+	}
+	return writeValues(w, ccs.CurveID(), witness)
+}
+
+// WriteFullFromJSON encodes the full witness of the compiled constraint system from a JSON object
+// (see WriteFullFromMap), and writes it on the provided writer
+func WriteFullFromJSON(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader) (int64, error) {
+	values, err := decodeJSON(r)
+	if err != nil {
+		return 0, err
+	}
+	return WriteFullFromMap(w, ccs, values)
+}
+
+// WritePublicFromJSON encodes the public witness of the compiled constraint system from a JSON object
+// (see WritePublicFromMap), and writes it on the provided writer
+func WritePublicFromJSON(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader) (int64, error) {
+	values, err := decodeJSON(r)
+	if err != nil {
+		return 0, err
+	}
+	return WritePublicFromMap(w, ccs, values)
+}
+
+// decodeJSON decodes a JSON object, the numbers are decoded as json.Number to preserve their precision
+func decodeJSON(r io.Reader) (map[string]interface{}, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var values map[string]interface{}
+	if err := dec.Decode(&values); err != nil {
+		return nil, fmt.Errorf("invalid JSON witness: %w", err)
+	}
+	return values, nil
+}
+
+// fromMap returns the values of the public inputs followed by the values of the secret inputs
+// (if public is not set), in the order of the witness
+func fromMap(ccs frontend.CompiledConstraintSystem, values map[string]interface{}, public bool) ([]big.Int, error) {
+	flat := make(map[string]interface{})
+	if err := flatten("", reflect.ValueOf(values), flat); err != nil {
+		return nil, err
+	}
+
+	names := ccs.PublicInputNames()
+	if !public {
+		names = append(names[:len(names):len(names)], ccs.SecretInputNames()...)
+	}
+
+	res := make([]big.Int, len(names))
+	for i, name := range names {
+		v, ok := flat[name]
+		if !ok {
+			return nil, errors.New("variable " + name + " not assigned")
+		}
+		if err := parseValue(&res[i], v); err != nil {
+			return nil, fmt.Errorf("variable %s: %w", name, err)
+		}
+		delete(flat, name)
+	}
+
+	// the public witness may be built from the full assignment
+	if public {
+		for _, name := range ccs.SecretInputNames() {
+			delete(flat, name)
+		}
+	}
+	if len(flat) != 0 {
+		unknown := make([]string, 0, len(flat))
+		for name := range flat {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, errors.New("unknown variable(s): " + strings.Join(unknown, ", "))
+	}
+
+	return res, nil
+}
+
+// flatten records the leaves of v in res, keyed by their names (see parser.Visit)
+func flatten(name string, v reflect.Value, res map[string]interface{}) error {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr && v.Elem().Kind() != reflect.Struct {
+		if v.IsNil() {
+			return errors.New("variable " + name + " is nil")
+		}
+		return flatten(name, v.Elem(), res)
+	}
+
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return errors.New("variable " + name + ": the keys of a map must be strings")
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := flatten(appendName(name, iter.Key().String()), iter.Value(), res); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := flatten(appendName(name, strconv.Itoa(i)), v.Index(i), res); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if _, ok := res[name]; ok {
+		return errors.New("variable " + name + " is assigned twice")
+	}
+	res[name] = v.Interface()
+	return nil
+}
+
+// appendName mirrors the naming of the nested variables of parser.Visit
+func appendName(baseName, name string) string {
+	if baseName == "" {
+		return name
+	}
+	return baseName + "_" + name
+}
+
+// parseValue sets res to the integer v
+func parseValue(res *big.Int, v interface{}) error {
+	switch v := v.(type) {
+	case json.Number:
+		if _, ok := res.SetString(string(v), 10); !ok {
+			return errors.New("invalid integer " + string(v))
+		}
+	case string:
+		// base 0: decimal, or hexadecimal with the "0x" prefix
+		if _, ok := res.SetString(v, 0); !ok {
+			return errors.New("invalid integer " + v)
+		}
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return fmt.Errorf("%v is not an exact integer", v)
+		}
+		res.SetInt64(int64(v))
+	case big.Int:
+		res.Set(&v)
+	case *big.Int:
+		res.Set(v)
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			res.SetInt64(rv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			res.SetUint64(rv.Uint())
+		default:
+			return fmt.Errorf("unsupported type %T", v)
+		}
+	}
+	return nil
+}
+
+// writeValues encodes the values in the binary format of a witness, and writes them on the provided writer
+func writeValues(w io.Writer, curveID ecc.ID, values []big.Int) (int64, error) {
+	return bigint.EncodingOf(curveID).WriteTo(w, values)
+}
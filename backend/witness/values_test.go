@@ -0,0 +1,137 @@
+package witness
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+)
+
+type transfer struct {
+	Amount frontend.Variable
+	Nonce  frontend.Variable
+}
+
+type valuesCircuit struct {
+	Transfers [2]transfer       `gnark:",public"`
+	Root      frontend.Variable `gnark:",public"`
+	Key       frontend.Variable
+}
+
+func (circuit *valuesCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	sum := cs.Add(circuit.Transfers[0].Amount, circuit.Transfers[1].Amount, circuit.Transfers[0].Nonce, circuit.Transfers[1].Nonce)
+	cs.AssertIsEqual(cs.Mul(sum, circuit.Key), circuit.Root)
+	return nil
+}
+
+func TestWriteFromValues(t *testing.T) {
+	const document = `{
+		"Transfers": [{"Amount": 1, "Nonce": "0x2"}, {"Amount": "3", "Nonce": 4}],
+		"Root": 50,
+		"Key": 5
+	}`
+	flat := map[string]interface{}{
+		"Transfers_0_Amount": 1,
+		"Transfers_0_Nonce":  uint64(2),
+		"Transfers_1_Amount": "3",
+		"Transfers_1_Nonce":  4,
+		"Root":               "0x32",
+		"Key":                5,
+	}
+
+	var w valuesCircuit
+	w.Transfers[0].Amount.Assign(1)
+	w.Transfers[0].Nonce.Assign(2)
+	w.Transfers[1].Amount.Assign(3)
+	w.Transfers[1].Nonce.Assign(4)
+	w.Root.Assign(50)
+	w.Key.Assign(5)
+
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+		for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+			var circuit valuesCircuit
+			ccs, err := frontend.Compile(curveID, zkpID, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var full, public bytes.Buffer
+			if _, err := WriteFullTo(&full, curveID, &w); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := WritePublicTo(&public, curveID, &w); err != nil {
+				t.Fatal(err)
+			}
+
+			check := func(name string, expected *bytes.Buffer, write func(*bytes.Buffer) error) {
+				var buf bytes.Buffer
+				if err := write(&buf); err != nil {
+					t.Fatalf("%s %v %s: %v", curveID, zkpID, name, err)
+				}
+				if !bytes.Equal(buf.Bytes(), expected.Bytes()) {
+					t.Fatalf("%s %v %s: wrong witness", curveID, zkpID, name)
+				}
+			}
+			check("full JSON", &full, func(buf *bytes.Buffer) error {
+				_, err := WriteFullFromJSON(buf, ccs, strings.NewReader(document))
+				return err
+			})
+			check("public JSON", &public, func(buf *bytes.Buffer) error {
+				_, err := WritePublicFromJSON(buf, ccs, strings.NewReader(document))
+				return err
+			})
+			check("full map", &full, func(buf *bytes.Buffer) error {
+				_, err := WriteFullFromMap(buf, ccs, flat)
+				return err
+			})
+			check("public map", &public, func(buf *bytes.Buffer) error {
+				_, err := WritePublicFromMap(buf, ccs, flat)
+				return err
+			})
+		}
+	}
+}
+
+func TestWriteFromValuesErrors(t *testing.T) {
+	var circuit valuesCircuit
+	ccs, err := frontend.Compile(ecc.BN254, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		document string
+		err      string
+	}{
+		{`{"Transfers": [{"Amount": 1, "Nonce": 2}, {"Amount": 3}], "Root": 50, "Key": 5}`, "Transfers_1_Nonce not assigned"},
+		{`{"Transfers": [{"Amount": 1, "Nonce": 2}, {"Amount": 3, "Nonce": 4}], "Root": 50, "Key": 5, "Salt": 1}`, "unknown variable(s): Salt"},
+		{`{"Transfers": [{"Amount": 1, "Nonce": 2}, {"Amount": 3, "Nonce": 4}], "Transfers_0_Amount": 1, "Root": 50, "Key": 5}`, "Transfers_0_Amount is assigned twice"},
+		{`{"Transfers": [{"Amount": 1, "Nonce": 2}, {"Amount": 3, "Nonce": 4}], "Root": 1.5, "Key": 5}`, "variable Root: invalid integer 1.5"},
+		{`{"Transfers": [{"Amount": 1, "Nonce": 2}, {"Amount": 3, "Nonce": 4}], "Root": true, "Key": 5}`, "variable Root: unsupported type bool"},
+		{`[1, 2]`, "invalid JSON witness"},
+	} {
+		_, err := WriteFullFromJSON(&bytes.Buffer{}, ccs, strings.NewReader(tc.document))
+		if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Fatalf("%s: expected error %q, got %v", tc.document, tc.err, err)
+		}
+	}
+}
+
+func TestWriteFromValuesUnknownCurve(t *testing.T) {
+	var circuit valuesCircuit
+	ccs, err := frontend.Compile(ecc.UNKNOWN, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const document = `{"Transfers": [{"Amount": 1, "Nonce": 2}, {"Amount": 3, "Nonce": 4}], "Root": 50, "Key": 5}`
+	if _, err := WriteFullFromJSON(&bytes.Buffer{}, ccs, strings.NewReader(document)); err == nil {
+		t.Fatal("encoding the full witness of a constraint system without curve should fail")
+	}
+	if _, err := WritePublicFromJSON(&bytes.Buffer{}, ccs, strings.NewReader(document)); err == nil {
+		t.Fatal("encoding the public witness of a constraint system without curve should fail")
+	}
+}
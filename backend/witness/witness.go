@@ -14,6 +14,11 @@
 
 // Package witness provides serialization helpers to encode a witness into a []byte.
 //
+// A witness is built from an assigned circuit (see WriteFullTo), or from the values of the inputs
+// keyed by their names, as a map or a JSON object (see WriteFullFromMap).
+// Conversely, a witness is encoded as a JSON object for inspection (see WriteFullJSONTo and FullBinaryToJSON).
+// The public witness is also encoded as the public.json file of snarkjs (see WritePublicSnarkJSTo).
+//
 // Binary protocol
 //
 // 	Full witness     ->  [uint32(nbElements) | publicVariables | secretVariables]
@@ -26,6 +31,7 @@
 // Ordering
 //
 // First, `publicVariables`, then `secretVariables`. Each subset is ordered from the order of definition in the circuit structure.
+// The public outputs (gnark:",output", see SolveOutputs) are public variables.
 // For example, with this circuit on `ecc.BN254`
 //
 // 	type Circuit struct {
@@ -41,9 +47,16 @@
 package witness
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"reflect"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/internal/backend/bigint"
+	"github.com/consensys/gnark/internal/backend/compiled"
+	"github.com/consensys/gnark/internal/parser"
+
 	witness_bls12377 "github.com/consensys/gnark/internal/backend/bls12-377/witness"
 	witness_bls12381 "github.com/consensys/gnark/internal/backend/bls12-381/witness"
 	witness_bn254 "github.com/consensys/gnark/internal/backend/bn254/witness"
@@ -115,3 +128,41 @@ func WritePublicTo(w io.Writer, curveID ecc.ID, publicWitness frontend.Circuit)
 		panic("not implemented")
 	}
 }
+
+// SolveOutputs solves the constraint system on the full witness, and assigns the public outputs
+// of the witness (see gnark:",output") to the values computed by the solver.
+//
+// The public outputs may be left unassigned in fullWitness: once SolveOutputs returns, fullWitness
+// can be used to build the public witness given to the verifier.
+func SolveOutputs(ccs frontend.CompiledConstraintSystem, fullWitness frontend.Circuit) error {
+	_ccs, ok := ccs.(bigint.ConstraintSystem)
+	if !ok {
+		return fmt.Errorf("%T can't be solved, compile it for a curve", ccs)
+	}
+	outputs, err := bigint.SolveOutputs(_ccs, fullWitness)
+	if err != nil {
+		return err
+	}
+
+	i := 0
+	var handler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
+		if visibility != compiled.Output {
+			return nil
+		}
+		if i >= len(outputs) {
+			return errors.New("witness doesn't match the constraint system")
+		}
+		var v frontend.Variable
+		v.Assign(outputs[i])
+		tInput.Set(reflect.ValueOf(v))
+		i++
+		return nil
+	}
+	if err := parser.Visit(fullWitness, "", compiled.Unset, handler, reflect.TypeOf(frontend.Variable{})); err != nil {
+		return err
+	}
+	if i != len(outputs) {
+		return errors.New("witness doesn't match the constraint system")
+	}
+	return nil
+}
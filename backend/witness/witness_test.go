@@ -0,0 +1,59 @@
+package witness
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+)
+
+type outputCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",output"`
+	Z frontend.Variable `gnark:",output"`
+}
+
+func (circuit *outputCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	circuit.Y = cs.Mul(circuit.X, circuit.X)
+	circuit.Z = cs.Add(circuit.Y, circuit.X, 1)
+	return nil
+}
+
+func TestSolveOutputs(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+		for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+			var circuit outputCircuit
+			ccs, err := frontend.Compile(curveID, zkpID, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var w outputCircuit
+			w.X.Assign(3)
+			if err := SolveOutputs(ccs, &w); err != nil {
+				t.Fatal(err)
+			}
+
+			y, z := frontend.GetAssignedValue(w.Y).(big.Int), frontend.GetAssignedValue(w.Z).(big.Int)
+			if y.Cmp(big.NewInt(9)) != 0 || z.Cmp(big.NewInt(13)) != 0 {
+				t.Fatalf("%s %v: wrong outputs Y = %s, Z = %s", curveID, zkpID, y.String(), z.String())
+			}
+		}
+	}
+}
+
+func TestSolveOutputsUnknownCurve(t *testing.T) {
+	var circuit outputCircuit
+	ccs, err := frontend.Compile(ecc.UNKNOWN, backend.GROTH16, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var w outputCircuit
+	w.X.Assign(3)
+	if err := SolveOutputs(ccs, &w); err == nil {
+		t.Fatal("solving a constraint system without curve should fail")
+	}
+}
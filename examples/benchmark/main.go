@@ -81,7 +81,7 @@ type benchCircuit struct {
 	n int
 }
 
-func (circuit *benchCircuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *benchCircuit) Define(curveID ecc.ID, cs frontend.API) error {
 	for i := 0; i < circuit.n; i++ {
 		circuit.X = cs.Mul(circuit.X, circuit.X)
 	}
@@ -18,26 +18,13 @@ type Circuit struct {
 
 // Define declares the circuit's constraints
 // y == x**e
-func (circuit *Circuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *Circuit) Define(curveID ecc.ID, cs frontend.API) error {
 
 	// number of bits of exponent
 	const bitSize = 8
 
 	// specify constraints
-	output := cs.Constant(1)
-	bits := cs.ToBinary(circuit.E, bitSize)
-	cs.ToBinary(circuit.E, bitSize)
-
-	for i := 0; i < len(bits); i++ {
-		// cs.Println(fmt.Sprintf("e[%d]", i), bits[i]) // we may print a variable for testing and / or debugging purposes
-
-		if i != 0 {
-			output = cs.Mul(output, output)
-		}
-		multiply := cs.Mul(output, circuit.X)
-		output = cs.Select(bits[len(bits)-1-i], multiply, output)
-
-	}
+	output := cs.Exp(circuit.X, circuit.E, bitSize)
 
 	cs.AssertIsEqual(circuit.Y, output)
 
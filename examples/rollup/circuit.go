@@ -17,6 +17,8 @@ limitations under the License.
 package rollup
 
 import (
+	"fmt"
+
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/accumulator/merkle"
@@ -87,7 +89,7 @@ type TransferConstraints struct {
 	Signature      eddsa.Signature
 }
 
-func (circuit *Circuit) postInit(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *Circuit) postInit(curveID ecc.ID, cs frontend.API) error {
 	// edward curve params
 	params, err := twistededwards.NewEdCurve(curveID)
 	if err != nil {
@@ -123,7 +125,7 @@ func (circuit *Circuit) postInit(curveID ecc.ID, cs *frontend.ConstraintSystem)
 }
 
 // Define declares the circuit's constraints
-func (circuit *Circuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *Circuit) Define(curveID ecc.ID, cs frontend.API) error {
 	if err := circuit.postInit(curveID, cs); err != nil {
 		return err
 	}
@@ -151,14 +153,23 @@ func (circuit *Circuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) er
 		}
 
 		// update the accounts
-		verifyAccountUpdated(cs, circuit.SenderAccountsBefore[i], circuit.ReceiverAccountsBefore[i], circuit.SenderAccountsAfter[i], circuit.ReceiverAccountsAfter[i], circuit.Transfers[i].Amount)
+		update := accountUpdate{
+			From:        circuit.SenderAccountsBefore[i],
+			To:          circuit.ReceiverAccountsBefore[i],
+			FromUpdated: circuit.SenderAccountsAfter[i],
+			ToUpdated:   circuit.ReceiverAccountsAfter[i],
+			Amount:      circuit.Transfers[i].Amount,
+		}
+		if err := cs.Call(fmt.Sprintf("accountUpdate%d", i), &update); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 // verifySignatureTransfer ensures that the signature of the transfer is valid
-func verifyTransferSignature(cs *frontend.ConstraintSystem, t TransferConstraints, hFunc mimc.MiMC) error {
+func verifyTransferSignature(cs frontend.API, t TransferConstraints, hFunc mimc.MiMC) error {
 
 	// the signature is on h(nonce || amount || senderpubKey (x&y) || receiverPubkey(x&y))
 	htransfer := hFunc.Hash(cs, t.Nonce, t.Amount, t.SenderPubKey.A.X, t.SenderPubKey.A.Y, t.ReceiverPubKey.A.X, t.ReceiverPubKey.A.Y)
@@ -170,21 +181,29 @@ func verifyTransferSignature(cs *frontend.ConstraintSystem, t TransferConstraint
 	return nil
 }
 
-func verifyAccountUpdated(cs *frontend.ConstraintSystem, from, to, fromUpdated, toUpdated AccountConstraints, amount frontend.Variable) {
+// accountUpdate is a component ensuring that the accounts are correctly updated by a transfer
+type accountUpdate struct {
+	From, To, FromUpdated, ToUpdated AccountConstraints
+	Amount                           frontend.Variable
+}
+
+// Define declares the component's constraints
+func (u *accountUpdate) Define(curveID ecc.ID, cs frontend.API) error {
 
 	// ensure that nonce is correctly updated
 	one := cs.Constant(1)
-	nonceUpdated := cs.Add(from.Nonce, one)
-	cs.AssertIsEqual(nonceUpdated, fromUpdated.Nonce)
+	nonceUpdated := cs.Add(u.From.Nonce, one)
+	cs.AssertIsEqual(nonceUpdated, u.FromUpdated.Nonce)
 
 	// ensures that the amount is less than the balance
-	cs.AssertIsLessOrEqual(amount, from.Balance)
+	cs.AssertIsLessOrEqual(u.Amount, u.From.Balance)
 
 	// ensure that balance is correctly updated
-	fromBalanceUpdated := cs.Sub(from.Balance, amount)
-	cs.AssertIsEqual(fromBalanceUpdated, fromUpdated.Balance)
+	fromBalanceUpdated := cs.Sub(u.From.Balance, u.Amount)
+	cs.AssertIsEqual(fromBalanceUpdated, u.FromUpdated.Balance)
 
-	toBalanceUpdated := cs.Add(to.Balance, amount)
-	cs.AssertIsEqual(toBalanceUpdated, toUpdated.Balance)
+	toBalanceUpdated := cs.Add(u.To.Balance, u.Amount)
+	cs.AssertIsEqual(toBalanceUpdated, u.ToUpdated.Balance)
 
+	return nil
 }
@@ -32,7 +32,7 @@ type circuitSignature struct {
 }
 
 // Circuit implements part of the rollup circuit only by delcaring a subset of the constraints
-func (t *circuitSignature) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (t *circuitSignature) Define(curveID ecc.ID, cs frontend.API) error {
 	if err := t.postInit(curveID, cs); err != nil {
 		return err
 	}
@@ -92,7 +92,7 @@ type circuitInclusionProof struct {
 }
 
 // Circuit implements part of the rollup circuit only by delcaring a subset of the constraints
-func (t *circuitInclusionProof) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (t *circuitInclusionProof) Define(curveID ecc.ID, cs frontend.API) error {
 	if err := t.postInit(curveID, cs); err != nil {
 		return err
 	}
@@ -160,13 +160,18 @@ type circuitUpdateAccount struct {
 }
 
 // Circuit implements part of the rollup circuit only by delcaring a subset of the constraints
-func (t *circuitUpdateAccount) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (t *circuitUpdateAccount) Define(curveID ecc.ID, cs frontend.API) error {
 	if err := t.postInit(curveID, cs); err != nil {
 		return err
 	}
-	verifyAccountUpdated(cs, t.SenderAccountsBefore[0], t.ReceiverAccountsBefore[0],
-		t.SenderAccountsAfter[0], t.ReceiverAccountsAfter[0], t.Transfers[0].Amount)
-	return nil
+	update := accountUpdate{
+		From:        t.SenderAccountsBefore[0],
+		To:          t.ReceiverAccountsBefore[0],
+		FromUpdated: t.SenderAccountsAfter[0],
+		ToUpdated:   t.ReceiverAccountsAfter[0],
+		Amount:      t.Transfers[0].Amount,
+	}
+	return cs.Call("accountUpdate", &update)
 }
 
 func TestCircuitUpdateAccount(t *testing.T) {
@@ -0,0 +1,161 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontend
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// maxOptimalChainBits is the size of the largest exponents for which the shortest addition chain is searched,
+// the addition chains of larger exponents are computed with the sliding window method
+const maxOptimalChainBits = 10
+
+// additionChain is an addition chain for e: a_0 = 1, a_(k+1) = a_i + a_j with i, j <= k, and the last term is e.
+// step k holds the indexes (i, j) of the terms of a_(k+1).
+//
+// Each step costs one multiplication when computing x^e (see ExpConstant).
+type additionChain [][2]int
+
+// newAdditionChain returns an addition chain for e > 0
+func newAdditionChain(e *big.Int) additionChain {
+	if e.BitLen() <= maxOptimalChainBits {
+		return shortestAdditionChain(e.Uint64())
+	}
+	return slidingWindowChain(e)
+}
+
+// shortestAdditionChain returns an addition chain of minimal length for e > 0,
+// found by an iterative deepening depth-first search
+func shortestAdditionChain(e uint64) additionChain {
+	chain := make([]uint64, 1, 2*bits.Len64(e))
+	chain[0] = 1
+
+	var search func(maxLen int) bool
+	search = func(maxLen int) bool {
+		last := chain[len(chain)-1]
+		if last == e {
+			return true
+		}
+		remaining := maxLen - (len(chain) - 1)
+		// each step at most doubles the last term
+		if remaining <= 0 || last<<uint(remaining) < e {
+			return false
+		}
+		// the terms are increasing, the sums are tried in decreasing order
+		for i := len(chain) - 1; i >= 0; i-- {
+			for j := i; j >= 0; j-- {
+				s := chain[i] + chain[j]
+				if s <= last {
+					break
+				}
+				if s > e {
+					continue
+				}
+				chain = append(chain, s)
+				if search(maxLen) {
+					return true
+				}
+				chain = chain[:len(chain)-1]
+			}
+		}
+		return false
+	}
+
+	// the length of an addition chain for e is at least log2(e)
+	for maxLen := bits.Len64(e) - 1; !search(maxLen); maxLen++ {
+	}
+
+	// recover the terms of each sum
+	res := make(additionChain, len(chain)-1)
+	for k := 1; k < len(chain); k++ {
+	lookup:
+		for i := k - 1; i >= 0; i-- {
+			for j := i; j >= 0; j-- {
+				if chain[i]+chain[j] == chain[k] {
+					res[k-1] = [2]int{i, j}
+					break lookup
+				}
+			}
+		}
+	}
+	return res
+}
+
+// slidingWindowChain returns an addition chain for e > 0 computed with the sliding window method:
+// the odd multiples 1, 3, ..., 2^w - 1 are computed first, then e is scanned from the most significant bit,
+// doubling for each bit and adding the value of each window of w bits starting and ending with a 1.
+func slidingWindowChain(e *big.Int) additionChain {
+	nbBits := e.BitLen()
+
+	// the window size minimizes the number of precomputed odd multiples and additions
+	w, cost := 1, nbBits
+	for k := 2; k <= 8; k++ {
+		if c := 1<<(k-1) + nbBits/(k+1); c < cost {
+			w, cost = k, c
+		}
+	}
+
+	var res additionChain
+	add := func(i, j int) int {
+		res = append(res, [2]int{i, j})
+		return len(res)
+	}
+
+	// odd[i] is the index of 2i+1
+	odd := make([]int, 1<<(w-1))
+	if w > 1 {
+		two := add(0, 0)
+		for i := 1; i < len(odd); i++ {
+			odd[i] = add(odd[i-1], two)
+		}
+	}
+
+	acc := -1
+	for i := nbBits - 1; i >= 0; {
+		if e.Bit(i) == 0 {
+			acc = add(acc, acc)
+			i--
+			continue
+		}
+
+		// longest window e[i..l] of at most w bits ending with a 1
+		l := i - w + 1
+		if l < 0 {
+			l = 0
+		}
+		for e.Bit(l) == 0 {
+			l++
+		}
+		var window uint
+		for k := i; k >= l; k-- {
+			window = window<<1 | e.Bit(k)
+			if acc != -1 {
+				acc = add(acc, acc)
+			}
+		}
+
+		if acc == -1 {
+			acc = odd[window>>1]
+		} else {
+			acc = add(acc, odd[window>>1])
+		}
+		i = l - 1
+	}
+
+	return res
+}
@@ -0,0 +1,48 @@
+package frontend
+
+import (
+	"math/big"
+	"testing"
+)
+
+// evaluate returns the last term of the addition chain
+func (chain additionChain) evaluate() *big.Int {
+	terms := []*big.Int{big.NewInt(1)}
+	for _, step := range chain {
+		terms = append(terms, new(big.Int).Add(terms[step[0]], terms[step[1]]))
+	}
+	return terms[len(terms)-1]
+}
+
+func TestAdditionChain(t *testing.T) {
+	// lengths of the shortest addition chains (OEIS A003313)
+	shortest := map[uint64]int{1: 0, 2: 1, 3: 2, 15: 5, 31: 7, 127: 10, 191: 11, 379: 12, 607: 13, 1023: 13}
+	for e, length := range shortest {
+		chain := shortestAdditionChain(e)
+		if len(chain) != length {
+			t.Fatalf("addition chain for %d: expected length %d, got %d", e, length, len(chain))
+		}
+		if chain.evaluate().Uint64() != e {
+			t.Fatalf("wrong addition chain for %d", e)
+		}
+	}
+
+	for e := uint64(1); e < 256; e++ {
+		if chain := newAdditionChain(new(big.Int).SetUint64(e)); chain.evaluate().Uint64() != e {
+			t.Fatalf("wrong addition chain for %d", e)
+		}
+	}
+
+	var e big.Int
+	for _, s := range []string{"2047", "65537", "1208925819614629174706175", "21888242871839275222246405745257275088548364400416034343698204186575808495615"} {
+		e.SetString(s, 10)
+		chain := newAdditionChain(&e)
+		if chain.evaluate().Cmp(&e) != 0 {
+			t.Fatalf("wrong addition chain for %s", s)
+		}
+		// the sliding window method needs at most log2(e) doublings, and fewer additions
+		if len(chain) >= 2*e.BitLen() {
+			t.Fatalf("addition chain for %s is too long: %d", s, len(chain))
+		}
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/internal/backend/compiled"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// ErrCircuitWarnings triggered when the analysis of a circuit reports warnings (see Strict)
+var ErrCircuitWarnings = errors.New("circuit analysis reported warnings")
+
+// WarningKind identifies the issue reported by a Warning
+type WarningKind uint8
+
+const (
+	// UnconstrainedInput is a public or secret input which doesn't appear in any constraint
+	UnconstrainedInput WarningKind = iota
+	// UnusedVariable is an internal variable which is computed but never used
+	UnusedVariable
+	// TrivialAssertion is an assertion which holds for any assignment
+	TrivialAssertion
+)
+
+func (k WarningKind) String() string {
+	switch k {
+	case UnconstrainedInput:
+		return "unconstrained input"
+	case UnusedVariable:
+		return "unused variable"
+	case TrivialAssertion:
+		return "trivially true assertion"
+	default:
+		return "unknown warning"
+	}
+}
+
+// Warning is an issue detected by the analysis of a circuit (see Analyze)
+//
+// Warnings don't prevent the circuit from being compiled, but usually point to a missing
+// constraint or to a useless computation.
+type Warning struct {
+	Kind     WarningKind
+	Name     string // name of the input (see parser.Visit), if Kind == UnconstrainedInput
+	Location string // call site in circuit.Define, if Kind == UnusedVariable or TrivialAssertion
+}
+
+func (w Warning) String() string {
+	if w.Kind == UnconstrainedInput {
+		return w.Kind.String() + ": " + w.Name
+	}
+	return w.Kind.String() + ": " + w.Location
+}
+
+// Analyze builds the constraint system of the circuit and returns the warnings reported
+// by the analysis:
+//
+//   - public or secret inputs which don't appear in any constraint
+//   - internal variables which are computed but not used in any constraint, hint or log
+//   - assertions which hold for any assignment (ex: cs.AssertIsEqual(x, x))
+//
+// The warnings are sorted in that order.
+func Analyze(curveID ecc.ID, circuit Circuit) ([]Warning, error) {
+	cs, err := buildCS(curveID, circuit, compileConfig{strict: true})
+	if err != nil {
+		return nil, err
+	}
+	return cs.analyze(), nil
+}
+
+// warningsError returns an error wrapping ErrCircuitWarnings listing the warnings
+func warningsError(warnings []Warning) error {
+	lines := make([]string, len(warnings))
+	for i := 0; i < len(warnings); i++ {
+		lines[i] = warnings[i].String()
+	}
+	return fmt.Errorf("%w:\n%s", ErrCircuitWarnings, strings.Join(lines, "\n"))
+}
+
+// analyze returns the warnings of the constraint system (see Analyze)
+//
+// the call sites of the internal variables and of the assertions are known
+// only if cs.recordCallSites is set
+func (cs *ConstraintSystem) analyze() []Warning {
+	var res []Warning
+
+	for _, name := range cs.unconstrainedInputs() {
+		res = append(res, Warning{Kind: UnconstrainedInput, Name: name})
+	}
+
+	for _, id := range cs.unusedVariables() {
+		w := Warning{Kind: UnusedVariable}
+		if id < len(cs.origins.internal) {
+			w.Location = cs.origins.internal[id]
+		}
+		res = append(res, w)
+	}
+
+	for i, r1c := range cs.assertions {
+		if !cs.isTrivial(r1c) {
+			continue
+		}
+		w := Warning{Kind: TrivialAssertion}
+		if i < len(cs.origins.assertions) {
+			w.Location = cs.origins.assertions[i]
+		}
+		res = append(res, w)
+	}
+
+	return res
+}
+
+// unusedVariables returns the ids of the internal variables which are computed but never used.
+//
+// A variable computed by a constraint is used if it appears in another constraint (or assertion),
+// in the inputs of a hint, or in a log. A variable computed by a hint is used if it appears
+// in a constraint, in the inputs of another hint, or in a log. Variables marked with markUnused are not reported.
+func (cs *ConstraintSystem) unusedVariables() []int {
+	nbUses := make([]int, len(cs.internal.variables))
+
+	// a variable appearing in several terms of a linear expression, or in L, R and O,
+	// is counted once per constraint
+	lastSeen := make([]int, len(cs.internal.variables))
+	for i := range lastSeen {
+		lastSeen[i] = -1
+	}
+	mark := func(l compiled.LinearExpression, seen int) {
+		for _, t := range l {
+			_, coeffID, variableID, visibility := t.Unpack()
+			if visibility != compiled.Internal || cs.coeffs[coeffID].Sign() == 0 {
+				continue
+			}
+			if lastSeen[variableID] != seen {
+				lastSeen[variableID] = seen
+				nbUses[variableID]++
+			}
+		}
+	}
+
+	seen := 0
+	for _, constraints := range [][]compiled.R1C{cs.constraints, cs.assertions} {
+		for _, r1c := range constraints {
+			mark(r1c.L, seen)
+			mark(r1c.R, seen)
+			mark(r1c.O, seen)
+			seen++
+		}
+	}
+	for _, h := range cs.hints {
+		for _, in := range h.Inputs {
+			mark(in, seen)
+		}
+		seen++
+	}
+	for _, l := range cs.logs {
+		mark(l.toResolve, seen)
+		seen++
+	}
+
+	// variables computed by a hint don't appear in a defining constraint
+	computedByHint := make(map[int]struct{}, len(cs.hints))
+	for _, h := range cs.hints {
+		computedByHint[h.WireID] = struct{}{}
+	}
+
+	var res []int
+	for id, n := range nbUses {
+		if _, ok := cs.internal.unused[id]; ok {
+			continue
+		}
+		minUses := 2
+		if _, ok := computedByHint[id]; ok {
+			minUses = 1
+		}
+		if n < minUses {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// markUnused marks v, if it is an internal variable, as left unused on purpose by a gadget:
+// it is not reported by the analysis of the circuit
+func (cs *ConstraintSystem) markUnused(v Variable) {
+	if len(v.linExp) != 1 {
+		return
+	}
+	_, _, variableID, visibility := v.linExp[0].Unpack()
+	if visibility == compiled.Internal {
+		cs.internal.unused[variableID] = struct{}{}
+	}
+}
+
+// isTrivial returns true if the assertion L * R == O holds for any assignment.
+//
+// Only the linear case is detected: L or R is a constant, and L * R - O is null.
+func (cs *ConstraintSystem) isTrivial(r1c compiled.R1C) bool {
+	var c *big.Int
+	var l compiled.LinearExpression
+	if v, ok := cs.constantValue(Variable{linExp: r1c.L}); ok {
+		c, l = v, r1c.R
+	} else if v, ok := cs.constantValue(Variable{linExp: r1c.R}); ok {
+		c, l = v, r1c.L
+	} else {
+		return false
+	}
+
+	// L * R - O, without recording new coefficients in the constraint system
+	type wireKey struct {
+		visibility compiled.Visibility
+		id         int
+	}
+	acc := make(map[wireKey]*big.Int)
+	add := func(l compiled.LinearExpression, scale *big.Int) {
+		for _, t := range l {
+			_, coeffID, variableID, visibility := t.Unpack()
+			k := wireKey{visibility, variableID}
+			if _, ok := acc[k]; !ok {
+				acc[k] = new(big.Int)
+			}
+			var tmp big.Int
+			tmp.Mul(&cs.coeffs[coeffID], scale)
+			acc[k].Add(acc[k], &tmp)
+		}
+	}
+	add(l, c)
+	add(r1c.O, bMinusOne)
+
+	q := utils.FrModulus(cs.curveID)
+	for _, v := range acc {
+		if q != nil {
+			v.Mod(v, q)
+		}
+		if v.Sign() != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// callSite returns the line of the innermost Define method (file:line) which leads to the caller of callSite,
+// followed by the path of the component being defined (if any), or "" if it is not called from a Define method
+func (cs *ConstraintSystem) callSite() string {
+	pc := make([]uintptr, 64)
+	n := runtime.Callers(3, pc)
+	if n == 0 {
+		return ""
+	}
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.Function, "Define") {
+			site := fmt.Sprintf("%s:%d", frame.File, frame.Line)
+			if path := cs.componentPath(); path != "" {
+				site += " (component " + path + ")"
+			}
+			return site
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package frontend
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+)
+
+type analysisCircuit struct {
+	X, Y   Variable
+	Unused Variable `gnark:",public"`
+}
+
+func (circuit *analysisCircuit) Define(curveID ecc.ID, cs API) error {
+	_ = cs.Mul(circuit.X, circuit.Y)
+	cs.AssertIsEqual(cs.Add(circuit.X, circuit.Y), cs.Add(circuit.Y, circuit.X))
+	cs.AssertIsLessOrEqual(circuit.X, circuit.Y)
+	cs.AssertIsLessOrEqual(circuit.X, 42)
+	return nil
+}
+
+type cleanCircuit struct {
+	X, Y Variable
+	Z    Variable `gnark:",public"`
+}
+
+func (circuit *cleanCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.AssertIsEqual(cs.Mul(circuit.X, circuit.Y), circuit.Z)
+	cs.AssertIsEqual(cs.IsEqual(circuit.X, 3), 0)
+	cs.AssertIsLessOrEqual(circuit.X, circuit.Y)
+	return nil
+}
+
+func TestAnalyze(t *testing.T) {
+
+	var circuit analysisCircuit
+	warnings, err := Analyze(ecc.BN254, &circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []WarningKind{UnconstrainedInput, UnusedVariable, TrivialAssertion}
+	if len(warnings) != len(expected) {
+		t.Fatalf("expected %d warnings, got %v", len(expected), warnings)
+	}
+	for i := 0; i < len(warnings); i++ {
+		if warnings[i].Kind != expected[i] {
+			t.Fatalf("expected %s, got %s", expected[i], warnings[i])
+		}
+	}
+	if warnings[0].Name != "Unused" {
+		t.Fatal("unconstrained input should be named after the circuit's field")
+	}
+	for i := 1; i < len(warnings); i++ {
+		if !strings.Contains(warnings[i].Location, "analysis_test.go") {
+			t.Fatal("warning should be located in Define", warnings[i].Location)
+		}
+	}
+
+	var clean cleanCircuit
+	warnings, err = Analyze(ecc.BN254, &clean)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Fatal("unexpected warnings", warnings)
+	}
+}
+
+func TestStrict(t *testing.T) {
+	for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+		var circuit analysisCircuit
+		if _, err := Compile(ecc.BN254, zkpID, &circuit, Strict()); !errors.Is(err, ErrCircuitWarnings) {
+			t.Fatal("expected ErrCircuitWarnings, got", err)
+		}
+
+		var clean cleanCircuit
+		if _, err := Compile(ecc.BN254, zkpID, &clean, Strict()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontend
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/hint"
+)
+
+// API represents the available functions to circuit developers (see Circuit.Define)
+//
+// It is implemented by ConstraintSystem, which records the constraints in order to compile the circuit,
+// and by the test engine (see gnark/frontend/test), which executes the circuit on concrete values.
+//
+// All the functions may take as input interface{}: these are either Variables or constants
+// (big.Int, strings, uint, fr.Element, see FromInterface)
+type API interface {
+	// Add returns res = i1+i2+...in
+	Add(i1, i2 interface{}, in ...interface{}) Variable
+
+	// Sub returns res = i1 - i2
+	Sub(i1, i2 interface{}) Variable
+
+	// Mul returns res = i1 * i2 * ... in
+	Mul(i1, i2 interface{}, in ...interface{}) Variable
+
+	// Inverse returns res = inverse(v)
+	Inverse(v Variable) Variable
+
+	// Div returns res = i1 / i2
+	Div(i1, i2 interface{}) Variable
+
+	// DivMod returns the quotient and the remainder of the integer division of a by b. a and b must fit on nbBits bits
+	DivMod(a, b Variable, nbBits int) (quotient, remainder Variable)
+
+	// Mod returns a mod m, where m is a positive constant
+	Mod(a Variable, m interface{}) Variable
+
+	// Xor compute the XOR between two variables
+	Xor(a, b Variable) Variable
+
+	// Or compute the OR between two variables
+	Or(a, b Variable) Variable
+
+	// And compute the AND between two variables
+	And(a, b Variable) Variable
+
+	// IsZero returns 1 if a is zero, 0 otherwise
+	IsZero(a Variable, id ecc.ID) Variable
+
+	// IsEqual returns 1 if i1 == i2, 0 otherwise
+	IsEqual(i1, i2 interface{}) Variable
+
+	// IsLessOrEqual returns 1 if i1 <= i2, 0 otherwise. i1 and i2 are range checked on nbBits bits
+	IsLessOrEqual(i1, i2 interface{}, nbBits int) Variable
+
+	// IsLess returns 1 if i1 < i2, 0 otherwise. i1 and i2 are range checked on nbBits bits
+	IsLess(i1, i2 interface{}, nbBits int) Variable
+
+	// Cmp returns 1 if i1 > i2, 0 if i1 == i2, -1 if i1 < i2. i1 and i2 are range checked on nbBits bits
+	Cmp(i1, i2 interface{}, nbBits int) Variable
+
+	// Exp returns res = base^exponent, exponent must fit on nbBits bits
+	Exp(base, exponent Variable, nbBits int) Variable
+
+	// ExpConstant returns res = base^exponent, where exponent is a non-negative constant
+	ExpConstant(base Variable, exponent interface{}) Variable
+
+	// ToBinary unpacks a variable in binary, n is the number of bits of the variable
+	ToBinary(a Variable, nbBits int) []Variable
+
+	// FromBinary packs b, seen as a fr.Element in little endian
+	FromBinary(b ...Variable) Variable
+
+	// Select if b is true, yields i1 else yields i2
+	Select(b Variable, i1, i2 interface{}) Variable
+
+	// Lookup2 returns i0 if b0=b1=0, i1 if b0=1 and b1=0, i2 if b0=0 and b1=1, i3 if b0=b1=1
+	Lookup2(b0, b1 Variable, i0, i1, i2, i3 interface{}) Variable
+
+	// Mux selects values[sel], fails if sel >= len(values)
+	Mux(sel Variable, values ...interface{}) Variable
+
+	// ArrayGet returns arr[index], fails if index >= len(arr)
+	ArrayGet(arr []Variable, index Variable) Variable
+
+	// NewHint initializes an internal variable whose value is computed by a hint function
+	NewHint(f hint.Function, inputs ...interface{}) Variable
+
+	// Constant returns a constant Variable
+	Constant(input interface{}) Variable
+
+	// AssertIsEqual fails if i1 != i2
+	AssertIsEqual(i1, i2 interface{})
+
+	// AssertIsBoolean fails if v != 0 && v != 1
+	AssertIsBoolean(v Variable)
+
+	// AssertIsLessOrEqual fails if v > bound
+	AssertIsLessOrEqual(v Variable, bound interface{})
+
+	// Println behaves like fmt.Println but accepts Variables as parameter
+	// whose value will be resolved at runtime when computed by the solver
+	Println(a ...interface{})
+
+	// Call calls component.Define, name identifies the instance of the component (see Component)
+	Call(name string, component Component) error
+}
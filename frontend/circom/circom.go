@@ -0,0 +1,249 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package circom reads the constraint systems (.r1cs) and the witnesses (.wtns) generated by circom,
+// so that circom circuits can be proven with gnark's Groth16 backend, and writes gnark's R1CS
+// and solved witnesses in the same formats, to be used with snarkjs and other circom tooling.
+//
+// The files follow the iden3 binary formats:
+//
+// https://github.com/iden3/r1csfile/blob/master/doc/r1cs_bin_format.md
+//
+// circom compiles circuits over the scalar field of BN254 (default) or BLS12-381: the curve of the
+// constraint system is the one which scalar field modulus is the prime of the file.
+package circom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// ErrInvalidFormat is returned when reading a file which doesn't follow the iden3 binary formats
+var ErrInvalidFormat = errors.New("invalid circom file")
+
+// maxElementSize is the size in bytes of the largest supported field elements
+const maxElementSize = 64
+
+// binFile is a file in the iden3 binary format
+//
+//	[magic | version | nbSections | sections]
+//
+// where magic is 4 bytes identifying the type of the file, version and nbSections are uint32,
+// and each section is [type (uint32) | size (uint64) | content]. All the integers are little-endian.
+type binFile struct {
+	version  uint32
+	sections map[uint32][]byte
+}
+
+// readBinFile reads a binary file of the given type (ex: "r1cs") and its sections
+func readBinFile(r io.Reader, fileType string, maxVersion uint32) (*binFile, error) {
+	var buf [12]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, truncated(err, "header")
+	}
+	if string(buf[:4]) != fileType {
+		return nil, fmt.Errorf("%w: not a .%s file", ErrInvalidFormat, fileType)
+	}
+
+	res := binFile{
+		version:  binary.LittleEndian.Uint32(buf[4:]),
+		sections: make(map[uint32][]byte),
+	}
+	if res.version == 0 || res.version > maxVersion {
+		return nil, fmt.Errorf("%w: unsupported .%s version %d", ErrInvalidFormat, fileType, res.version)
+	}
+
+	nbSections := binary.LittleEndian.Uint32(buf[8:])
+	for i := uint32(0); i < nbSections; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, truncated(err, "section header")
+		}
+		sectionType := binary.LittleEndian.Uint32(buf[:4])
+		size := binary.LittleEndian.Uint64(buf[4:])
+		if _, ok := res.sections[sectionType]; ok {
+			return nil, fmt.Errorf("%w: duplicated section %d", ErrInvalidFormat, sectionType)
+		}
+
+		// the content is not allocated upfront, as the size may be corrupted
+		var content bytes.Buffer
+		if _, err := io.CopyN(&content, r, int64(size)); err != nil {
+			return nil, truncated(err, fmt.Sprintf("section %d", sectionType))
+		}
+		res.sections[sectionType] = content.Bytes()
+	}
+
+	return &res, nil
+}
+
+// writeBinFile writes a binary file of the given type and its sections
+func writeBinFile(w io.Writer, fileType string, version uint32, sections ...*sectionWriter) (int64, error) {
+	var buf [12]byte
+	copy(buf[:4], fileType)
+	binary.LittleEndian.PutUint32(buf[4:], version)
+	binary.LittleEndian.PutUint32(buf[8:], uint32(len(sections)))
+	n, err := w.Write(buf[:])
+	if err != nil {
+		return int64(n), err
+	}
+
+	for _, section := range sections {
+		binary.LittleEndian.PutUint32(buf[:4], section.sectionType)
+		binary.LittleEndian.PutUint64(buf[4:], uint64(section.Len()))
+		m, err := w.Write(buf[:])
+		n += m
+		if err != nil {
+			return int64(n), err
+		}
+		m, err = w.Write(section.Bytes())
+		n += m
+		if err != nil {
+			return int64(n), err
+		}
+	}
+	return int64(n), nil
+}
+
+// section returns a reader on the content of a mandatory section
+func (f *binFile) section(sectionType uint32, name string) (*sectionReader, error) {
+	content, ok := f.sections[sectionType]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing %s section", ErrInvalidFormat, name)
+	}
+	return &sectionReader{data: content, name: name}, nil
+}
+
+// truncated wraps the errors of io.ReadFull and io.CopyN, the unexpected ends of file are format errors
+func truncated(err error, what string) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return fmt.Errorf("%w: truncated %s", ErrInvalidFormat, what)
+	}
+	return err
+}
+
+// sectionReader decodes the content of a section. Once an error occurred, the decoded values are zeros
+// and the error is returned by Err.
+type sectionReader struct {
+	data []byte
+	name string
+	err  error
+}
+
+func (s *sectionReader) next(n int) []byte {
+	if s.err == nil && len(s.data) < n {
+		s.err = fmt.Errorf("%w: truncated %s section", ErrInvalidFormat, s.name)
+	}
+	if s.err != nil {
+		return make([]byte, n)
+	}
+	res := s.data[:n]
+	s.data = s.data[n:]
+	return res
+}
+
+func (s *sectionReader) uint32() uint32 {
+	return binary.LittleEndian.Uint32(s.next(4))
+}
+
+func (s *sectionReader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(s.next(8))
+}
+
+// element decodes an integer of n8 bytes (little-endian) in res
+func (s *sectionReader) element(res *big.Int, n8 int) {
+	buf := s.next(n8)
+	be := make([]byte, n8)
+	for i := 0; i < n8; i++ {
+		be[i] = buf[n8-1-i]
+	}
+	res.SetBytes(be)
+}
+
+// fieldElement decodes an element of the field of the given prime in res
+func (s *sectionReader) fieldElement(res *big.Int, n8 int, prime *big.Int) {
+	s.element(res, n8)
+	if s.err == nil && res.Cmp(prime) >= 0 {
+		s.err = fmt.Errorf("%w: %s is not a field element", ErrInvalidFormat, res.String())
+	}
+}
+
+// Err returns the first error which occurred, or an error if the section isn't fully decoded
+func (s *sectionReader) Err() error {
+	if s.err == nil && len(s.data) != 0 {
+		return fmt.Errorf("%w: unexpected data at the end of the %s section", ErrInvalidFormat, s.name)
+	}
+	return s.err
+}
+
+// sectionWriter encodes the content of a section
+type sectionWriter struct {
+	bytes.Buffer
+	sectionType uint32
+}
+
+func (s *sectionWriter) uint32(v uint32) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	s.Write(buf[:])
+}
+
+func (s *sectionWriter) uint64(v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	s.Write(buf[:])
+}
+
+// element encodes v < 2^(8*n8) as an integer of n8 bytes (little-endian)
+func (s *sectionWriter) element(v *big.Int, n8 int) {
+	buf := v.FillBytes(make([]byte, n8))
+	for i := n8 - 1; i >= 0; i-- {
+		s.WriteByte(buf[i])
+	}
+}
+
+// writeField encodes the size in bytes of the field elements and the prime (see readField)
+func writeField(s *sectionWriter, n8 int, prime *big.Int) {
+	s.uint32(uint32(n8))
+	s.element(prime, n8)
+}
+
+// readField decodes the size in bytes of the field elements and the prime, which start the header
+// of the .r1cs and .wtns files
+func readField(s *sectionReader) (n8 int, prime *big.Int, err error) {
+	n8 = int(s.uint32())
+	if s.err == nil && (n8 == 0 || n8 > maxElementSize || n8%8 != 0) {
+		return 0, nil, fmt.Errorf("%w: unsupported field element size %d", ErrInvalidFormat, n8)
+	}
+	prime = new(big.Int)
+	s.element(prime, n8)
+	return n8, prime, s.err
+}
+
+// curveOf returns the curve which scalar field is defined by prime
+func curveOf(prime *big.Int) (ecc.ID, error) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381, ecc.BLS12_377, ecc.BW6_761} {
+		if utils.FrModulus(curveID).Cmp(prime) == 0 {
+			return curveID, nil
+		}
+	}
+	return ecc.UNKNOWN, fmt.Errorf("unsupported field: %s is not the scalar field modulus of a supported curve", prime.String())
+}
@@ -0,0 +1,375 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/circuits"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// testFiles builds the .r1cs and .wtns files of the circom circuit
+//
+//	template Example() {
+//		signal output out;  // wire 1
+//		signal input x;     // wire 2, public
+//		signal private input a; // wire 3
+//		signal private input b; // wire 4
+//		signal t;           // wire 5
+//		t <== a * b;
+//		out <== 7 * t + x;
+//	}
+//
+// with x = 4, a = 3 and b = 11
+func testFiles(curveID ecc.ID) (r1cs, wtns []byte) {
+	prime := utils.FrModulus(curveID)
+	n8 := 32
+	if curveID == ecc.BW6_761 {
+		n8 = 48
+	}
+
+	header := sectionWriter{sectionType: r1csHeaderSection}
+	writeField(&header, n8, prime)
+	header.uint32(6) // wires
+	header.uint32(1) // public outputs
+	header.uint32(1) // public inputs
+	header.uint32(2) // private inputs
+	header.uint64(6) // labels
+	header.uint32(2) // constraints
+
+	type term struct {
+		wire  uint32
+		coeff int64
+	}
+	constraints := sectionWriter{sectionType: r1csConstraintsSection}
+	for _, r1c := range [][3][]term{
+		{{{3, 1}}, {{4, 1}}, {{5, 1}}},
+		{{{5, 7}, {2, 1}}, {{0, 1}}, {{1, 1}}},
+	} {
+		for _, l := range r1c {
+			constraints.uint32(uint32(len(l)))
+			for _, t := range l {
+				constraints.uint32(t.wire)
+				constraints.element(big.NewInt(t.coeff), n8)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := writeBinFile(&buf, "r1cs", r1csVersion, &header, &constraints); err != nil {
+		panic(err)
+	}
+	r1cs = append([]byte{}, buf.Bytes()...)
+
+	header = sectionWriter{sectionType: wtnsHeaderSection}
+	writeField(&header, n8, prime)
+	header.uint32(6)
+	values := sectionWriter{sectionType: wtnsValuesSection}
+	for _, v := range []int64{1, 235, 4, 3, 11, 33} {
+		values.element(big.NewInt(v), n8)
+	}
+	buf.Reset()
+	if _, err := writeBinFile(&buf, "wtns", wtnsVersion, &header, &values); err != nil {
+		panic(err)
+	}
+	wtns = buf.Bytes()
+
+	return
+}
+
+func TestReadR1CS(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		r1csFile, wtnsFile := testFiles(curveID)
+
+		ccs, err := ReadR1CS(bytes.NewReader(r1csFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ccs.CurveID() != curveID {
+			t.Fatalf("expected a constraint system over %s, got %s", curveID, ccs.CurveID())
+		}
+		internal, secret, public := ccs.GetNbVariables()
+		if internal != 0 || secret != 3 || public != 3 {
+			t.Fatalf("unexpected number of variables: %d internal, %d secret, %d public", internal, secret, public)
+		}
+		if !reflect.DeepEqual(ccs.PublicInputNames(), []string{"wire_1", "wire_2"}) ||
+			!reflect.DeepEqual(ccs.SecretInputNames(), []string{"wire_3", "wire_4", "wire_5"}) {
+			t.Fatalf("unexpected input names %v %v", ccs.PublicInputNames(), ccs.SecretInputNames())
+		}
+
+		var fullWitness, publicWitness bytes.Buffer
+		if _, err := WriteFullWitness(&fullWitness, ccs, bytes.NewReader(wtnsFile)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := WritePublicWitness(&publicWitness, ccs, bytes.NewReader(wtnsFile)); err != nil {
+			t.Fatal(err)
+		}
+
+		pk, vk, err := groth16.Setup(ccs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof, err := groth16.ReadAndProve(ccs, pk, &fullWitness)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := groth16.ReadAndVerify(proof, vk, &publicWitness); err != nil {
+			t.Fatal(err)
+		}
+
+		// out != 7 * a * b + x
+		bad := make([]byte, len(wtnsFile))
+		copy(bad, wtnsFile)
+		bad[len(bad)-6*32+32]++
+		fullWitness.Reset()
+		if _, err := WriteFullWitness(&fullWitness, ccs, bytes.NewReader(bad)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := groth16.ReadAndProve(ccs, pk, &fullWitness); err == nil {
+			t.Fatal("proving with a wrong witness should fail")
+		}
+	}
+}
+
+// readTestFile reads a file generated by circom or snarkjs for the curve (see testdata/README.md),
+// and skips the test if it is missing
+func readTestFile(t *testing.T, curveID ecc.ID, name string) []byte {
+	path := filepath.Join("testdata", curveID.String(), name)
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Skipf("%s is missing (see testdata/README.md)", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// TestCircomFiles proves and verifies the circuit testdata/example.circom, compiled by circom
+func TestCircomFiles(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		t.Run(curveID.String(), func(t *testing.T) {
+			r1csFile := readTestFile(t, curveID, "example.r1cs")
+			wtnsFile := readTestFile(t, curveID, "example.wtns")
+
+			ccs, err := ReadR1CS(bytes.NewReader(r1csFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ccs.CurveID() != curveID {
+				t.Fatalf("expected a constraint system over %s, got %s", curveID, ccs.CurveID())
+			}
+
+			var fullWitness, publicWitness bytes.Buffer
+			if _, err := WriteFullWitness(&fullWitness, ccs, bytes.NewReader(wtnsFile)); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := WritePublicWitness(&publicWitness, ccs, bytes.NewReader(wtnsFile)); err != nil {
+				t.Fatal(err)
+			}
+
+			// out = 7 * a * b + x, x = 4, a = 3 and b = 11
+			var expected bytes.Buffer
+			if _, err := witness.WritePublicFromMap(&expected, ccs, map[string]interface{}{"wire_1": 235, "wire_2": 4}); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(publicWitness.Bytes(), expected.Bytes()) {
+				t.Fatal("unexpected public witness")
+			}
+
+			pk, vk, err := groth16.Setup(ccs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, err := groth16.ReadAndProve(ccs, pk, &fullWitness)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := groth16.ReadAndVerify(proof, vk, &publicWitness); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestSnarkJSFiles verifies the proof of testdata/example.circom generated by snarkjs,
+// with the verifying key exported by snarkjs
+func TestSnarkJSFiles(t *testing.T) {
+	for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_381} {
+		t.Run(curveID.String(), func(t *testing.T) {
+			r1csFile := readTestFile(t, curveID, "example.r1cs")
+			vkFile := readTestFile(t, curveID, "verification_key.json")
+			proofFile := readTestFile(t, curveID, "proof.json")
+			publicFile := readTestFile(t, curveID, "public.json")
+
+			ccs, err := ReadR1CS(bytes.NewReader(r1csFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			vk := groth16.NewVerifyingKey(curveID)
+			if err := vk.ImportSnarkJS(bytes.NewReader(vkFile)); err != nil {
+				t.Fatal(err)
+			}
+			proof := groth16.NewProof(curveID)
+			if err := proof.ImportSnarkJS(bytes.NewReader(proofFile)); err != nil {
+				t.Fatal(err)
+			}
+			var publicWitness bytes.Buffer
+			if _, err := witness.WritePublicFromSnarkJS(&publicWitness, ccs, bytes.NewReader(publicFile)); err != nil {
+				t.Fatal(err)
+			}
+			if err := groth16.ReadAndVerify(proof, vk, bytes.NewReader(publicWitness.Bytes())); err != nil {
+				t.Fatal(err)
+			}
+
+			// out = 236 instead of 235
+			var wrongPublicWitness bytes.Buffer
+			if _, err := witness.WritePublicFromMap(&wrongPublicWitness, ccs, map[string]interface{}{"wire_1": 236, "wire_2": 4}); err != nil {
+				t.Fatal(err)
+			}
+			if err := groth16.ReadAndVerify(proof, vk, &wrongPublicWitness); err == nil {
+				t.Fatal("verifying with a wrong public witness should fail")
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	r1csFile, wtnsFile := testFiles(ecc.BN254)
+
+	invalid := func(name string, r1cs []byte) {
+		t.Helper()
+		if _, err := ReadR1CS(bytes.NewReader(r1cs)); !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("%s: expected an invalid format error, got %v", name, err)
+		}
+	}
+	invalid("wtns", wtnsFile)
+	invalid("truncated", r1csFile[:len(r1csFile)-1])
+	invalid("version", append(append([]byte("r1cs"), 2, 0, 0, 0), r1csFile[8:]...))
+
+	// wire of the first term out of range: it follows the file header, the header section,
+	// the header of the constraints section and the number of terms
+	outOfRange := make([]byte, len(r1csFile))
+	copy(outOfRange, r1csFile)
+	binary.LittleEndian.PutUint32(outOfRange[12+(12+64)+12+4:], 6)
+	invalid("wire out of range", outOfRange)
+
+	// the field of BLS12-377 is not the field of BN254
+	ccs, err := ReadR1CS(bytes.NewReader(r1csFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, wtnsBLS12377 := testFiles(ecc.BLS12_377)
+	if _, err := WriteFullWitness(&bytes.Buffer{}, ccs, bytes.NewReader(wtnsBLS12377)); err == nil {
+		t.Fatal("reading a witness over another field should fail")
+	}
+
+	// not a prime of a supported curve
+	header := sectionWriter{sectionType: r1csHeaderSection}
+	writeField(&header, 32, big.NewInt(101))
+	var buf bytes.Buffer
+	if _, err := writeBinFile(&buf, "r1cs", r1csVersion, &header); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadR1CS(&buf); err == nil {
+		t.Fatal("reading a constraint system over an unsupported field should fail")
+	}
+}
+
+func TestWriteR1CS(t *testing.T) {
+	for name, circuit := range circuits.Circuits {
+		t.Run(name, func(t *testing.T) {
+			ccs, err := frontend.Compile(ecc.BN254, backend.GROTH16, circuit.Circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if testing.Short() && ccs.GetNbConstraints() > 50 {
+				t.Skip("large circuit")
+			}
+
+			var r1csFile, wtnsFile bytes.Buffer
+			if _, err := WriteR1CS(&r1csFile, ccs); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := WriteWitness(&wtnsFile, ccs, circuit.Good); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := WriteWitness(&bytes.Buffer{}, ccs, circuit.Bad); err == nil {
+				t.Fatal("solving with a bad witness should fail")
+			}
+
+			// the constraint system and the witness read back are proven and verified with Groth16
+			imported, err := ReadR1CS(&r1csFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if imported.GetNbConstraints() != ccs.GetNbConstraints() {
+				t.Fatalf("expected %d constraints, got %d", ccs.GetNbConstraints(), imported.GetNbConstraints())
+			}
+
+			var fullWitness, publicWitness bytes.Buffer
+			if _, err := WriteFullWitness(&fullWitness, imported, bytes.NewReader(wtnsFile.Bytes())); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := WritePublicWitness(&publicWitness, imported, bytes.NewReader(wtnsFile.Bytes())); err != nil {
+				t.Fatal(err)
+			}
+
+			// the public witness of the exported constraint system is the public witness of the gnark circuit
+			var expectedPublicWitness bytes.Buffer
+			if _, err := witness.WritePublicTo(&expectedPublicWitness, ecc.BN254, circuit.Good); err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(publicWitness.Bytes(), expectedPublicWitness.Bytes()) {
+				t.Fatal("the public witnesses don't match")
+			}
+
+			pk, vk, err := groth16.Setup(imported)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, err := groth16.ReadAndProve(imported, pk, &fullWitness)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := groth16.ReadAndVerify(proof, vk, &publicWitness); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+
+	// PLONK constraint systems are not R1CS
+	ccs, err := frontend.Compile(ecc.BN254, backend.PLONK, circuits.Circuits["reference_small"].Circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteR1CS(&bytes.Buffer{}, ccs); err == nil {
+		t.Fatal("exporting a SparseR1CS should fail")
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/bigint"
+	bls12377r1cs "github.com/consensys/gnark/internal/backend/bls12-377/cs"
+	bls12381r1cs "github.com/consensys/gnark/internal/backend/bls12-381/cs"
+	bn254r1cs "github.com/consensys/gnark/internal/backend/bn254/cs"
+	bw6761r1cs "github.com/consensys/gnark/internal/backend/bw6-761/cs"
+	"github.com/consensys/gnark/internal/backend/compiled"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// sections of a .r1cs file
+const (
+	r1csHeaderSection      uint32 = 1
+	r1csConstraintsSection uint32 = 2
+	r1csWire2LabelSection  uint32 = 3
+	r1csCustomGatesList    uint32 = 4
+	r1csCustomGatesApplied uint32 = 5
+)
+
+const r1csVersion = 1
+
+// maxNbWires is the number of wires which can be encoded in a compiled.Term
+const maxNbWires = 1 << 29
+
+// ReadR1CS reads a constraint system in the .r1cs format generated by circom (circom --r1cs),
+// and returns the equivalent R1CS, to be used with the Groth16 backend.
+//
+// The wires of a circom circuit are [ONE_WIRE | public outputs | public inputs | private inputs | internal wires],
+// the internal wires being computed by the witness generator of circom rather than by the constraints.
+// Hence, the internal wires are secret inputs of the returned R1CS, which constraints are only assertions:
+//
+//	public inputs = [public outputs | public inputs]
+//	secret inputs = [private inputs | internal wires]
+//
+// and the witness is read from the .wtns file generated by circom (see WriteFullWitness).
+// The input named "wire_<i>" is the i-th wire of the circom circuit.
+func ReadR1CS(r io.Reader) (frontend.CompiledConstraintSystem, error) {
+	f, err := readBinFile(r, "r1cs", r1csVersion)
+	if err != nil {
+		return nil, err
+	}
+	if len(f.sections[r1csCustomGatesList]) != 0 || len(f.sections[r1csCustomGatesApplied]) != 0 {
+		return nil, fmt.Errorf("%w: custom gates are not supported", ErrInvalidFormat)
+	}
+
+	// header
+	s, err := f.section(r1csHeaderSection, "header")
+	if err != nil {
+		return nil, err
+	}
+	n8, prime, err := readField(s)
+	if err != nil {
+		return nil, err
+	}
+	curveID, err := curveOf(prime)
+	if err != nil {
+		return nil, err
+	}
+	nbWires := s.uint32()
+	nbPublicOutputs := s.uint32()
+	nbPublicInputs := s.uint32()
+	nbPrivateInputs := s.uint32()
+	_ = s.uint64() // number of labels (see the wire2label section)
+	nbConstraints := s.uint32()
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	nbPublic := 1 + uint64(nbPublicOutputs) + uint64(nbPublicInputs)
+	if nbPublic+uint64(nbPrivateInputs) > uint64(nbWires) {
+		return nil, fmt.Errorf("%w: %d wires can't hold the %d inputs", ErrInvalidFormat, nbWires, nbPublic-1+uint64(nbPrivateInputs))
+	}
+	if nbWires >= maxNbWires {
+		return nil, fmt.Errorf("too many wires: %d", nbWires)
+	}
+
+	res := compiled.R1CS{
+		NbPublicVariables: int(nbPublic),
+		NbSecretVariables: int(nbWires) - int(nbPublic),
+		NbConstraints:     int(nbConstraints),
+		PublicNames:       make([]string, 0, nbPublic-1),
+		SecretNames:       make([]string, 0, int(nbWires)-int(nbPublic)),
+	}
+	for i := 1; i < int(nbWires); i++ {
+		if i < res.NbPublicVariables {
+			res.PublicNames = append(res.PublicNames, wireName(i))
+		} else {
+			res.SecretNames = append(res.SecretNames, wireName(i))
+		}
+	}
+
+	// constraints
+	s, err = f.section(r1csConstraintsSection, "constraints")
+	if err != nil {
+		return nil, err
+	}
+	t := coeffTable{
+		ids: make(map[string]int),
+	}
+	t.minusOne.Sub(prime, big.NewInt(1))
+	var coeff big.Int
+	readLinearExpression := func() compiled.LinearExpression {
+		nbTerms := s.uint32()
+		// each term takes at least 4 + n8 bytes, the allocation is bounded by the size of the section
+		if s.err == nil && uint64(nbTerms)*uint64(4+n8) > uint64(len(s.data)) {
+			s.err = fmt.Errorf("%w: truncated constraints section", ErrInvalidFormat)
+		}
+		if s.err != nil {
+			return nil
+		}
+		l := make(compiled.LinearExpression, nbTerms)
+		for i := 0; i < len(l); i++ {
+			wireID := s.uint32()
+			s.fieldElement(&coeff, n8, prime)
+			if s.err == nil && wireID >= nbWires {
+				s.err = fmt.Errorf("%w: wire %d out of range", ErrInvalidFormat, wireID)
+			}
+			if s.err != nil {
+				return nil
+			}
+			visibility := compiled.Secret
+			if int(wireID) < res.NbPublicVariables {
+				visibility = compiled.Public
+			}
+			l[i] = t.makeTerm(int(wireID), visibility, &coeff)
+		}
+		return l
+	}
+	for i := uint32(0); i < nbConstraints && s.err == nil; i++ {
+		var r1c compiled.R1C
+		r1c.L = readLinearExpression()
+		r1c.R = readLinearExpression()
+		r1c.O = readLinearExpression()
+		res.Constraints = append(res.Constraints, r1c)
+		res.DebugInfo = append(res.DebugInfo, compiled.LogEntry{
+			Format: "constraint #" + strconv.Itoa(int(i)) + " of the circom circuit is not satisfied",
+		})
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	switch curveID {
+	case ecc.BN254:
+		return bn254r1cs.NewR1CS(res, t.coeffs), nil
+	case ecc.BLS12_381:
+		return bls12381r1cs.NewR1CS(res, t.coeffs), nil
+	case ecc.BLS12_377:
+		return bls12377r1cs.NewR1CS(res, t.coeffs), nil
+	case ecc.BW6_761:
+		return bw6761r1cs.NewR1CS(res, t.coeffs), nil
+	default:
+		panic("not implemented")
+	}
+}
+
+// WriteR1CS writes a R1CS (compiled for the Groth16 backend) in the .r1cs format of circom
+//
+// The wires of the R1CS keep their index: the ONE_WIRE, the public inputs (without public outputs
+// in the circom sense, the outputs of the gnark circuit being public inputs) and the secret inputs are followed
+// by the internal wires. The label of each wire is its index.
+func WriteR1CS(w io.Writer, ccs frontend.CompiledConstraintSystem) (int64, error) {
+	r1cs, coeffs, err := toCompiledR1CS(ccs)
+	if err != nil {
+		return 0, err
+	}
+	prime := utils.FrModulus(ccs.CurveID())
+	n8 := ccs.FrSize()
+	nbWires := r1cs.NbPublicVariables + r1cs.NbSecretVariables + r1cs.NbInternalVariables
+	if uint64(nbWires) > math.MaxUint32 || uint64(len(r1cs.Constraints)) > math.MaxUint32 {
+		return 0, errors.New("the constraint system is too large for the .r1cs format")
+	}
+
+	header := sectionWriter{sectionType: r1csHeaderSection}
+	writeField(&header, n8, prime)
+	header.uint32(uint32(nbWires))
+	header.uint32(0) // public outputs
+	header.uint32(uint32(r1cs.NbPublicVariables - 1))
+	header.uint32(uint32(r1cs.NbSecretVariables))
+	header.uint64(uint64(nbWires)) // labels
+	header.uint32(uint32(len(r1cs.Constraints)))
+
+	constraints := sectionWriter{sectionType: r1csConstraintsSection}
+	var minusOne big.Int
+	minusOne.Sub(prime, big.NewInt(1))
+	sums := make(map[int]*big.Int)
+	for i := 0; i < len(r1cs.Constraints); i++ {
+		for _, l := range [3]compiled.LinearExpression{r1cs.Constraints[i].L, r1cs.Constraints[i].R, r1cs.Constraints[i].O} {
+			// a wire appears at most once in a circom linear expression, with a non zero coefficient
+			wireIDs := make([]int, 0, len(l))
+			for _, t := range l {
+				var coeff *big.Int
+				switch t.CoeffValue() {
+				case 0:
+					continue
+				case 1:
+					coeff = big.NewInt(1)
+				case 2:
+					coeff = big.NewInt(2)
+				case -1:
+					coeff = &minusOne
+				default:
+					coeff = &coeffs[t.CoeffID()]
+				}
+				sum, ok := sums[t.VariableID()]
+				if !ok {
+					sum = new(big.Int)
+					sums[t.VariableID()] = sum
+					wireIDs = append(wireIDs, t.VariableID())
+				}
+				sum.Add(sum, coeff).Mod(sum, prime)
+			}
+			sort.Ints(wireIDs)
+
+			nbTerms := 0
+			for _, wireID := range wireIDs {
+				if sums[wireID].Sign() != 0 {
+					nbTerms++
+				}
+			}
+			constraints.uint32(uint32(nbTerms))
+			for _, wireID := range wireIDs {
+				if sums[wireID].Sign() != 0 {
+					constraints.uint32(uint32(wireID))
+					constraints.element(sums[wireID], n8)
+				}
+				delete(sums, wireID)
+			}
+		}
+	}
+
+	wire2Label := sectionWriter{sectionType: r1csWire2LabelSection}
+	for i := 0; i < nbWires; i++ {
+		wire2Label.uint64(uint64(i))
+	}
+
+	return writeBinFile(w, "r1cs", r1csVersion, &header, &constraints, &wire2Label)
+}
+
+// toCompiledR1CS returns the compiled R1CS underlying ccs and its coefficients
+func toCompiledR1CS(ccs frontend.CompiledConstraintSystem) (*compiled.R1CS, []big.Int, error) {
+	r1cs, ok := ccs.(bigint.R1CS)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T is not a R1CS (see backend.GROTH16)", ccs)
+	}
+	r, coeffs := r1cs.ToCompiled()
+	return r, coeffs, nil
+}
+
+// wireName returns the name of the input held by the i-th wire of a circom circuit
+func wireName(i int) string {
+	return "wire_" + strconv.Itoa(i)
+}
+
+// coeffTable records the unique coefficients of a constraint system
+type coeffTable struct {
+	coeffs   []big.Int
+	ids      map[string]int // key = coeff.Text(16)
+	minusOne big.Int        // modulus - 1
+}
+
+// makeTerm packs a wire and a coefficient in a compiled.Term, as the frontend does
+func (t *coeffTable) makeTerm(wireID int, visibility compiled.Visibility, coeff *big.Int) compiled.Term {
+	key := coeff.Text(16)
+	coeffID, ok := t.ids[key]
+	if !ok {
+		var cCopy big.Int
+		cCopy.Set(coeff)
+		coeffID = len(t.coeffs)
+		t.coeffs = append(t.coeffs, cCopy)
+		t.ids[key] = coeffID
+	}
+
+	term := compiled.Pack(wireID, coeffID, visibility)
+	if coeff.IsUint64() && coeff.Uint64() <= 2 {
+		term.SetCoeffValue(int(coeff.Uint64()))
+	} else if coeff.Cmp(&t.minusOne) == 0 {
+		term.SetCoeffValue(-1)
+	}
+	return term
+}
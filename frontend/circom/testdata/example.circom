@@ -0,0 +1,14 @@
+pragma circom 2.0.0;
+
+// the circuit of testFiles (circom_test.go)
+template Example() {
+    signal input x;
+    signal input a;
+    signal input b;
+    signal output out;
+    signal t;
+    t <== a * b;
+    out <== 7 * t + x;
+}
+
+component main {public [x]} = Example();
@@ -0,0 +1,139 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package circom
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/bigint"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// sections of a .wtns file
+const (
+	wtnsHeaderSection uint32 = 1
+	wtnsValuesSection uint32 = 2
+)
+
+const wtnsVersion = 2
+
+// WriteFullWitness reads a witness in the .wtns format generated by circom (the values of all the wires
+// of the circuit), and writes the full witness of ccs on the provided writer, in the binary format of
+// package backend/witness.
+//
+// ccs must have been read with ReadR1CS, from the .r1cs file of the same circuit.
+func WriteFullWitness(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader) (int64, error) {
+	values, err := readWitness(ccs, r)
+	if err != nil {
+		return 0, err
+	}
+	return witness.WriteFullFromMap(w, ccs, values)
+}
+
+// WritePublicWitness reads a witness in the .wtns format generated by circom, and writes the public witness
+// of ccs on the provided writer (see WriteFullWitness)
+func WritePublicWitness(w io.Writer, ccs frontend.CompiledConstraintSystem, r io.Reader) (int64, error) {
+	values, err := readWitness(ccs, r)
+	if err != nil {
+		return 0, err
+	}
+	return witness.WritePublicFromMap(w, ccs, values)
+}
+
+// WriteWitness solves the R1CS (compiled for the Groth16 backend) with the full witness, and writes
+// the values of all its wires in the .wtns format of circom (see WriteR1CS)
+func WriteWitness(w io.Writer, ccs frontend.CompiledConstraintSystem, fullWitness frontend.Circuit) (int64, error) {
+	r1cs, ok := ccs.(bigint.R1CS)
+	if !ok {
+		return 0, fmt.Errorf("%T is not a R1CS (see backend.GROTH16)", ccs)
+	}
+	values, _, err := bigint.Solve(r1cs, fullWitness, false)
+	if err != nil {
+		return 0, err
+	}
+
+	n8 := ccs.FrSize()
+	header := sectionWriter{sectionType: wtnsHeaderSection}
+	writeField(&header, n8, utils.FrModulus(ccs.CurveID()))
+	header.uint32(uint32(len(values)))
+
+	wires := sectionWriter{sectionType: wtnsValuesSection}
+	for i := 0; i < len(values); i++ {
+		wires.element(&values[i], n8)
+	}
+
+	return writeBinFile(w, "wtns", wtnsVersion, &header, &wires)
+}
+
+// readWitness reads a .wtns file, and returns the values of the inputs of ccs, keyed by their names
+func readWitness(ccs frontend.CompiledConstraintSystem, r io.Reader) (map[string]interface{}, error) {
+	internal, secret, public := ccs.GetNbVariables()
+	if internal != 0 {
+		return nil, errors.New("the constraint system was not read from a .r1cs file (see ReadR1CS)")
+	}
+
+	f, err := readBinFile(r, "wtns", wtnsVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// header
+	s, err := f.section(wtnsHeaderSection, "header")
+	if err != nil {
+		return nil, err
+	}
+	n8, prime, err := readField(s)
+	if err != nil {
+		return nil, err
+	}
+	nbWires := s.uint32()
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if prime.Cmp(utils.FrModulus(ccs.CurveID())) != 0 {
+		return nil, fmt.Errorf("the witness is not defined over the scalar field of %s", ccs.CurveID().String())
+	}
+	if int(nbWires) != public+secret {
+		return nil, fmt.Errorf("invalid witness size, got %d wires, expected %d", nbWires, public+secret)
+	}
+
+	// values, the first wire is the constant 1
+	s, err = f.section(wtnsValuesSection, "values")
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]interface{}, nbWires)
+	var one big.Int
+	s.fieldElement(&one, n8, prime)
+	if s.err == nil && !(one.IsUint64() && one.Uint64() == 1) {
+		return nil, fmt.Errorf("%w: the first wire must be 1", ErrInvalidFormat)
+	}
+	for i := 1; i < int(nbWires) && s.err == nil; i++ {
+		v := new(big.Int)
+		s.fieldElement(v, n8, prime)
+		res[wireName(i)] = v
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -20,5 +20,5 @@ import "github.com/consensys/gnark-crypto/ecc"
 // it is then the developer responsability to do circuit.Z = circuit.Y in the Define() method
 type Circuit interface {
 	// Define declares the circuit's Constraints
-	Define(curveID ecc.ID, cs *ConstraintSystem) error
+	Define(curveID ecc.ID, cs API) error
 }
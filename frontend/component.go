@@ -0,0 +1,99 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontend
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// Component is a reusable part of a circuit, which can be instantiated several times (see API.Call)
+//
+// The inputs and the outputs of a component are fields of the struct: the parent circuit sets the inputs
+// before calling the component, and Define sets the outputs. For example:
+//
+//	type Square struct {
+//		X frontend.Variable // input
+//		Y frontend.Variable // output
+//	}
+//	func (c *Square) Define(curveID ecc.ID, cs frontend.API) error {
+//		c.Y = cs.Mul(c.X, c.X)
+//		return nil
+//	}
+//
+// and in the Define method of the parent circuit:
+//
+//	square := Square{X: circuit.X}
+//	if err := cs.Call("square", &square); err != nil {
+//		return err
+//	}
+//	cs.AssertIsEqual(square.Y, circuit.Y)
+//
+// Unlike the fields of a Circuit, the fields of a component are not inputs of the constraint system.
+type Component interface {
+	// Define declares the component's Constraints
+	Define(curveID ecc.ID, cs API) error
+}
+
+// componentFrame is a component being defined
+type componentFrame struct {
+	path  string   // names of the enclosing components and of the component, separated by "/"
+	stack []string // call stack of the parent, from the caller of Call to the parent's Define
+}
+
+// Call calls component.Define. name identifies the instance of the component in the debug info,
+// in the errors and in the profile (see WithProfile): the constraints recorded by the component
+// are attributed to the path of the instance (ex: "transfer0/signature").
+func (cs *ConstraintSystem) Call(name string, component Component) error {
+	frame := componentFrame{path: name}
+	if !cs.noDebugInfo || cs.profile != nil {
+		// the stack completes the debug info and the profile (see withComponents)
+		frame.stack = callStack(3)
+	}
+	if n := len(cs.components); n > 0 {
+		frame.path = cs.components[n-1].path + "/" + name
+	}
+
+	cs.components = append(cs.components, frame)
+	defer func() {
+		cs.components = cs.components[:len(cs.components)-1]
+	}()
+
+	if err := component.Define(cs.curveID, cs); err != nil {
+		return fmt.Errorf("component %s: %w", frame.path, err)
+	}
+	return nil
+}
+
+// withComponents completes a call stack ending at the Define method of the innermost component
+// with the enclosing components and the call stacks of their parents
+func (cs *ConstraintSystem) withComponents(stack []string) []string {
+	for i := len(cs.components) - 1; i >= 0; i-- {
+		stack = append(stack, "component "+cs.components[i].path)
+		stack = append(stack, cs.components[i].stack...)
+	}
+	return stack
+}
+
+// componentPath returns the path of the component being defined, or "" if none
+func (cs *ConstraintSystem) componentPath() string {
+	if len(cs.components) == 0 {
+		return ""
+	}
+	return cs.components[len(cs.components)-1].path
+}
@@ -0,0 +1,103 @@
+package frontend
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+)
+
+// cubeComponent computes Y = X**3, using a squareComponent
+type cubeComponent struct {
+	X, Y Variable
+}
+
+func (c *cubeComponent) Define(curveID ecc.ID, cs API) error {
+	square := squareComponent{X: c.X}
+	if err := cs.Call("square", &square); err != nil {
+		return err
+	}
+	c.Y = cs.Mul(square.Y, c.X)
+	return nil
+}
+
+// squareComponent computes Y = X**2, and checks that X != Forbidden
+type squareComponent struct {
+	X, Y      Variable
+	Forbidden interface{}
+}
+
+func (c *squareComponent) Define(curveID ecc.ID, cs API) error {
+	if c.Forbidden != nil {
+		cs.AssertIsEqual(cs.IsEqual(c.X, c.Forbidden), 0)
+	}
+	c.Y = cs.Mul(c.X, c.X)
+	return nil
+}
+
+type cubeCircuit struct {
+	X, Y Variable
+	Z    Variable `gnark:",public"`
+}
+
+func (circuit *cubeCircuit) Define(curveID ecc.ID, cs API) error {
+	cubes := []cubeComponent{{X: circuit.X}, {X: circuit.Y}}
+	if err := cs.Call("cube0", &cubes[0]); err != nil {
+		return err
+	}
+	if err := cs.Call("cube1", &cubes[1]); err != nil {
+		return err
+	}
+	cs.AssertIsEqual(cs.Add(cubes[0].Y, cubes[1].Y), circuit.Z)
+	return nil
+}
+
+type forbiddenCircuit struct {
+	X Variable
+}
+
+func (circuit *forbiddenCircuit) Define(curveID ecc.ID, cs API) error {
+	square := squareComponent{X: circuit.X}
+	if err := cs.Call("input", &square); err != nil {
+		return err
+	}
+
+	// the inputs of a component may be constants
+	square = squareComponent{X: cs.Constant(3), Forbidden: 3}
+	return cs.Call("constant", &square)
+}
+
+func TestComponent(t *testing.T) {
+	var circuit cubeCircuit
+	var p Profile
+	if _, err := Compile(ecc.BN254, backend.GROTH16, &circuit, WithProfile(&p)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the constraints are attributed to the instances of the components
+	cumulative := p.Cumulative()
+	if cumulative["component cube0"] != 2 || cumulative["component cube1"] != 2 || cumulative["component cube0/square"] != 1 {
+		t.Fatal("wrong number of constraints attributed to the components", p.String())
+	}
+	if cumulative["frontend.(*cubeComponent).Define"] != 4 {
+		t.Fatal("the constraints of both instances should be attributed to cubeComponent.Define", p.String())
+	}
+
+	// the profile doesn't depend on the debug info
+	var pNoDebugInfo Profile
+	if _, err := Compile(ecc.BN254, backend.GROTH16, &circuit, WithProfile(&pNoDebugInfo), WithoutDebugInfo()); err != nil {
+		t.Fatal(err)
+	}
+	if pNoDebugInfo.String() != p.String() {
+		t.Fatal("the profile changed without debug info", pNoDebugInfo.String())
+	}
+
+	// errors are located in the components
+	var forbidden forbiddenCircuit
+	_, err := Compile(ecc.BN254, backend.GROTH16, &forbidden)
+	if !errors.Is(err, backend.ErrUnsatisfiedConstraint) || !strings.Contains(err.Error(), "component constant") {
+		t.Fatal("error should locate the component", err)
+	}
+}
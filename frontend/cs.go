@@ -26,7 +26,9 @@ import (
 	"strings"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/internal/backend/compiled"
+	"github.com/consensys/gnark/internal/utils"
 )
 
 // ConstraintSystem represents a Groth16 like circuit
@@ -39,15 +41,18 @@ type ConstraintSystem struct {
 	// Variables (aka wires)
 	public struct {
 		variables []Variable       // public inputs
+		names     []string         // names of the public inputs (see parser.Visit), names[0] is the ONE_WIRE
 		booleans  map[int]struct{} // keep track of boolean variables (we constrain them once)
 	}
 	secret struct {
 		variables []Variable       // secret inputs
+		names     []string         // names of the secret inputs (see parser.Visit)
 		booleans  map[int]struct{} // keep track of boolean variables (we constrain them once)
 	}
 	internal struct {
 		variables []Variable       // internal variables
 		booleans  map[int]struct{} // keep track of boolean variables (we constrain them once)
+		unused    map[int]struct{} // variables computed by a gadget and left unused on purpose (see markUnused)
 	}
 
 	// Constraints
@@ -58,11 +63,35 @@ type ConstraintSystem struct {
 	coeffs    []big.Int      // list of unique coefficients.
 	coeffsIDs map[string]int // map to fast check existence of a coefficient (key = coeff.Text(16))
 
+	// Hints
+	hints []hintEntry // list of internal variables computed by a hint function (see NewHint)
+
+	// Public outputs
+	outputs []output // list of public variables computed by the circuit (see gnark:",output")
+
 	// debug info
 	logs           []logEntry // list of logs to be printed when solving a circuit. The logs are called with the method Println
 	debugInfo      []logEntry // list of logs storing information about assertions. If an assertion fails, it prints it in a friendly format
 	unsetVariables []logEntry // unset variables. If a variable is unset, the error is caught when compiling the circuit
 
+	curveID        ecc.ID // curve for which the circuit is compiled, the operations on constants are done modulo its scalar field (if known)
+	minModulusBits int    // if curveID is unknown, minimum bit length of the scalar field modulus required by the gadgets (see DivMod)
+	err            error  // first error detected while building the constraint system (ex: assertion between constants which doesn't hold)
+
+	// compile options (see CompileOption)
+	noDebugInfo    bool // if set, the call stacks are not captured
+	maxConstraints int  // if > 0, maximum number of constraints
+
+	// call sites in circuit.Define, recorded for the analysis of the circuit (see Analyze)
+	recordCallSites bool
+	origins         struct {
+		internal   []string // call site of each internal variable
+		assertions []string // call site of each assertion
+	}
+
+	profile *Profile // if set, constraints are attributed to their call stacks (see WithProfile)
+
+	components []componentFrame // components being defined (see Call), from the outermost
 }
 
 // CompiledConstraintSystem ...
@@ -75,6 +104,12 @@ type CompiledConstraintSystem interface {
 	GetNbConstraints() int
 	GetNbCoefficients() int
 
+	// PublicInputNames returns the names of the public inputs, in the order of the public witness
+	// (see parser.Visit, ex: "Transfers_0_Amount")
+	PublicInputNames() []string
+	// SecretInputNames returns the names of the secret inputs, in the order of the witness
+	SecretInputNames() []string
+
 	// SetLoggerOutput replace existing logger output with provided one
 	SetLoggerOutput(w io.Writer)
 
@@ -102,9 +137,11 @@ func newConstraintSystem() ConstraintSystem {
 
 	cs.internal.variables = make([]Variable, 0, initialCapacity)
 	cs.internal.booleans = make(map[int]struct{})
+	cs.internal.unused = make(map[int]struct{})
 
 	// by default the circuit is given on public wire equal to 1
 	cs.public.variables[0] = cs.newPublicVariable()
+	cs.public.names = []string{"ONE_WIRE"}
 
 	return cs
 }
@@ -114,6 +151,18 @@ type logEntry struct {
 	toResolve []compiled.Term
 }
 
+type output struct {
+	name  string        // name of the output (see parser.Visit)
+	field reflect.Value // field of the circuit, assigned in circuit.Define
+	wire  Variable      // public variable allocated for the output
+	value int           // internal variable holding the value of the output, once bound (see bindOutputs)
+}
+
+type hintEntry struct {
+	compiled.Hint
+	nbConstraints int // number of constraints recorded when the hint was created
+}
+
 var (
 	bMinusOne = new(big.Int).SetInt64(-1)
 	bZero     = new(big.Int)
@@ -122,10 +171,18 @@ var (
 )
 
 // debug info in case a variable is not set
-func debugInfoUnsetVariable(term compiled.Term) logEntry {
+func (cs *ConstraintSystem) debugInfoUnsetVariable(term compiled.Term) logEntry {
 	entry := logEntry{}
-	stack := getCallStack()
-	entry.format = stack[len(stack)-1]
+	if !cs.noDebugInfo {
+		// the line of the innermost Define method
+		stack := callStack(3)
+		if len(stack) > 0 {
+			entry.format = stack[len(stack)-1]
+		}
+		if path := cs.componentPath(); path != "" {
+			entry.format += "\ncomponent " + path
+		}
+	}
 	entry.toResolve = append(entry.toResolve, term)
 	return entry
 }
@@ -229,7 +286,7 @@ func (cs *ConstraintSystem) completeDanglingVariable(v *Variable) {
 	if len(v.linExp) == 0 {
 		tmp := Wire{compiled.Unset, v.id, v.val}
 		tmpVar := cs.buildVarFromWire(tmp)
-		cs.unsetVariables = append(cs.unsetVariables, debugInfoUnsetVariable(tmpVar.linExp[0]))
+		cs.unsetVariables = append(cs.unsetVariables, cs.debugInfoUnsetVariable(tmpVar.linExp[0]))
 		v.linExp = tmpVar.linExp // .Clone()
 	}
 }
@@ -253,10 +310,88 @@ func (cs *ConstraintSystem) reduce(l compiled.LinearExpression) compiled.LinearE
 	return res
 }
 
+// addConstraint records a constraint yielding an output
+func (cs *ConstraintSystem) addConstraint(constraint compiled.R1C) {
+	cs.constraints = append(cs.constraints, constraint)
+	if cs.profile != nil {
+		cs.profile.record(cs.withComponents(callStack(3)))
+	}
+	cs.checkMaxConstraints()
+}
+
 func (cs *ConstraintSystem) addAssertion(constraint compiled.R1C, debugInfo logEntry) {
 
 	cs.assertions = append(cs.assertions, constraint)
 	cs.debugInfo = append(cs.debugInfo, debugInfo)
+	if cs.recordCallSites {
+		cs.origins.assertions = append(cs.origins.assertions, cs.callSite())
+	}
+	if cs.profile != nil {
+		cs.profile.record(cs.withComponents(callStack(3)))
+	}
+	cs.checkMaxConstraints()
+}
+
+// checkMaxConstraints records an error if the maximum number of constraints is exceeded (see WithMaxConstraints)
+func (cs *ConstraintSystem) checkMaxConstraints() {
+	if cs.maxConstraints > 0 && cs.NbConstraints() > cs.maxConstraints {
+		cs.addError("%w: %d", ErrMaxConstraints, cs.maxConstraints)
+	}
+}
+
+// publicInputNames returns a copy of the names of the public inputs, without the ONE_WIRE
+func (cs *ConstraintSystem) publicInputNames() []string {
+	res := make([]string, len(cs.public.names)-1)
+	copy(res, cs.public.names[1:])
+	return res
+}
+
+// secretInputNames returns a copy of the names of the secret inputs
+func (cs *ConstraintSystem) secretInputNames() []string {
+	res := make([]string, len(cs.secret.names))
+	copy(res, cs.secret.names)
+	return res
+}
+
+// unconstrainedInputs returns the names of the public and secret inputs
+// which are not involved in any constraint
+func (cs *ConstraintSystem) unconstrainedInputs() []string {
+	public := make([]bool, len(cs.public.variables))
+	secret := make([]bool, len(cs.secret.variables))
+	mark := func(l compiled.LinearExpression) {
+		for _, t := range l {
+			_, coeffID, variableID, visibility := t.Unpack()
+			if cs.coeffs[coeffID].Sign() == 0 {
+				continue
+			}
+			switch visibility {
+			case compiled.Public:
+				public[variableID] = true
+			case compiled.Secret:
+				secret[variableID] = true
+			}
+		}
+	}
+	for _, constraints := range [][]compiled.R1C{cs.constraints, cs.assertions} {
+		for _, r1c := range constraints {
+			mark(r1c.L)
+			mark(r1c.R)
+			mark(r1c.O)
+		}
+	}
+
+	var res []string
+	for i := 1; i < len(public); i++ { // public[0] is the ONE_WIRE
+		if !public[i] && i < len(cs.public.names) {
+			res = append(res, cs.public.names[i])
+		}
+	}
+	for i := 0; i < len(secret); i++ {
+		if !secret[i] && i < len(cs.secret.names) {
+			res = append(res, cs.secret.names[i])
+		}
+	}
+	return res
 }
 
 // coeffID tries to fetch the entry where b is if it exits, otherwise appends b to
@@ -278,6 +413,131 @@ func (cs *ConstraintSystem) coeffID(b *big.Int) int {
 	return resID
 }
 
+// constantValue returns the value of i1 if it is a constant, or a Variable whose linear expression
+// only involves the ONE_WIRE. The value is reduced modulo the scalar field if the curve is known.
+func (cs *ConstraintSystem) constantValue(i1 interface{}) (*big.Int, bool) {
+	res := new(big.Int)
+
+	switch t := i1.(type) {
+	case Variable:
+		if len(t.linExp) == 0 {
+			return nil, false // dangling variable
+		}
+		for i := 0; i < len(t.linExp); i++ {
+			_, coeffID, variableID, visibility := t.linExp[i].Unpack()
+			if cs.coeffs[coeffID].Sign() == 0 {
+				continue
+			}
+			if visibility != compiled.Public || variableID != 0 {
+				return nil, false
+			}
+			res.Add(res, &cs.coeffs[coeffID])
+		}
+	default:
+		n := FromInterface(t)
+		res.Set(&n)
+	}
+
+	if q := utils.FrModulus(cs.curveID); q != nil {
+		res.Mod(res, q)
+	}
+	return res, true
+}
+
+// addError records an error detected while building the constraint system, which is returned
+// by Compile. Only the first error is kept.
+//
+// The error is formatted as with fmt.Errorf.
+func (cs *ConstraintSystem) addError(format string, a ...interface{}) {
+	if cs.err != nil {
+		return
+	}
+	err := fmt.Errorf(format, a...)
+	var msg string
+	stack := cs.getCallStack()
+	for i := 0; i < len(stack); i++ {
+		msg += "\n" + stack[i]
+	}
+	cs.err = fmt.Errorf("%w%s", err, msg)
+}
+
+// addUnsatisfiedError records an error wrapping backend.ErrUnsatisfiedConstraint (see addError):
+// an operation or an assertion between constants doesn't hold
+func (cs *ConstraintSystem) addUnsatisfiedError(format string, a ...interface{}) {
+	cs.addError("%w: %s", backend.ErrUnsatisfiedConstraint, fmt.Sprintf(format, a...))
+}
+
+// booleans returns the set of boolean wires with provided visibility
+func (cs *ConstraintSystem) booleans(visibility compiled.Visibility) map[int]struct{} {
+	switch visibility {
+	case compiled.Public:
+		return cs.public.booleans
+	case compiled.Secret:
+		return cs.secret.booleans
+	case compiled.Internal:
+		return cs.internal.booleans
+	default:
+		return nil
+	}
+}
+
+// markBoolean records that v is constrained to be boolean, so that the boolean constraint
+// is not added again (see AssertIsBoolean). Only variables consisting of a single wire
+// (with coefficient 1) are recorded.
+func (cs *ConstraintSystem) markBoolean(v Variable) {
+	if len(v.linExp) != 1 {
+		return
+	}
+	_, coeffID, variableID, visibility := v.linExp[0].Unpack()
+	if visibility == compiled.Public && variableID == 0 {
+		return // ONE_WIRE
+	}
+	if cs.coeffs[coeffID].Cmp(bOne) != 0 {
+		return
+	}
+	if booleans := cs.booleans(visibility); booleans != nil {
+		booleans[variableID] = struct{}{}
+	}
+}
+
+// isBoolean returns true if v is known to be boolean, that is if v is
+// 0, 1, a boolean wire (see markBoolean) or 1 - a boolean wire
+func (cs *ConstraintSystem) isBoolean(v Variable) bool {
+	var constant big.Int
+	var wire *compiled.Term
+	for i := 0; i < len(v.linExp); i++ {
+		_, coeffID, variableID, visibility := v.linExp[i].Unpack()
+		if cs.coeffs[coeffID].Sign() == 0 {
+			continue
+		}
+		if visibility == compiled.Public && variableID == 0 {
+			constant.Add(&constant, &cs.coeffs[coeffID]) // ONE_WIRE
+			continue
+		}
+		if wire != nil {
+			return false
+		}
+		wire = &v.linExp[i]
+	}
+
+	if wire == nil {
+		return constant.Sign() == 0 || constant.Cmp(bOne) == 0
+	}
+
+	_, coeffID, variableID, visibility := wire.Unpack()
+	booleans := cs.booleans(visibility)
+	if booleans == nil {
+		return false
+	}
+	if _, ok := booleans[variableID]; !ok {
+		return false
+	}
+
+	coeff := &cs.coeffs[coeffID]
+	return (coeff.Cmp(bOne) == 0 && constant.Sign() == 0) ||
+		(coeff.Cmp(bMinusOne) == 0 && constant.Cmp(bOne) == 0)
+}
+
 // if v is unset and linExp is non empty, the variable is allocated
 // resulting in one more constraint in the system. If v is set OR v is
 // unset and linexp is emppty, it does nothing.
@@ -285,7 +545,7 @@ func (cs *ConstraintSystem) allocate(v Variable) Variable {
 	if v.visibility == compiled.Unset && len(v.linExp) > 0 {
 		iv := cs.newInternalVariable()
 		one := cs.getOneVariable()
-		cs.constraints = append(cs.constraints, newR1C(v, one, iv))
+		cs.addConstraint(newR1C(v, one, iv))
 		return iv
 	}
 	return v
@@ -294,12 +554,16 @@ func (cs *ConstraintSystem) allocate(v Variable) Variable {
 // newInternalVariable creates a new wire, appends it on the list of wires of the circuit, sets
 // the wire's id to the number of wires, and returns it
 func (cs *ConstraintSystem) newInternalVariable() Variable {
+	cs.checkMaxConstraints()
 	resVar := Wire{
 		id:         len(cs.internal.variables),
 		visibility: compiled.Internal,
 	}
 	res := cs.buildVarFromWire(resVar)
 	cs.internal.variables = append(cs.internal.variables, res)
+	if cs.recordCallSites {
+		cs.origins.internal = append(cs.origins.internal, cs.callSite())
+	}
 	return res
 }
 
@@ -371,10 +635,21 @@ func parseLogValue(input interface{}, name string, handler logValueHandler) {
 
 // derived from: https://golang.org/pkg/runtime/#example_Frames
 // we stop when func name == Define as it is where the gnark circuit code should start
-func getCallStack() []string {
-	// Ask runtime.Callers for up to 10 pcs
-	pc := make([]uintptr, 10)
-	n := runtime.Callers(3, pc)
+// getCallStack returns the call stack, up to the Define method of the circuit,
+// or nil if the debug info are disabled (see WithoutDebugInfo)
+func (cs *ConstraintSystem) getCallStack() []string {
+	if cs.noDebugInfo {
+		return nil
+	}
+	return cs.withComponents(callStack(4))
+}
+
+// callStack returns the call stack up to circuit.Define, skipping the first skip frames
+// (see runtime.Callers)
+func callStack(skip int) []string {
+	// Ask runtime.Callers for up to 32 pcs
+	pc := make([]uintptr, 32)
+	n := runtime.Callers(skip, pc)
 	if n == 0 {
 		// No pcs available. Stop now.
 		// This can happen if the first argument to runtime.Callers is large.
@@ -401,5 +676,5 @@ func getCallStack() []string {
 }
 
 func (cs *ConstraintSystem) buildVarFromWire(pv Wire) Variable {
-	return Variable{pv, cs.LinearExpression(cs.makeTerm(pv, bOne)), false}
+	return Variable{pv, cs.LinearExpression(cs.makeTerm(pv, bOne))}
 }
@@ -19,19 +19,18 @@ package frontend
 import (
 	"fmt"
 	"math/big"
+	"math/bits"
 	"path/filepath"
 	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
 
+	"github.com/consensys/gnark/backend/hint"
 	"github.com/consensys/gnark/internal/backend/compiled"
+	"github.com/consensys/gnark/internal/utils"
 
 	"github.com/consensys/gnark-crypto/ecc"
-	frbls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
-	frbls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
-	frbn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
-	frbw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
 )
 
 // Add returns res = i1+i2+...in
@@ -113,7 +112,7 @@ func (cs *ConstraintSystem) mulConstant(i interface{}, v Variable) Variable {
 		coeffCopy.Mul(&coeff, &lambda)
 		linExp = append(linExp, cs.makeTerm(Wire{constraintVis, variableID, nil}, &coeffCopy))
 	}
-	return Variable{Wire{}, linExp, false}
+	return Variable{Wire{}, linExp}
 }
 
 // Mul returns res = i1 * i2 * ... in
@@ -127,8 +126,15 @@ func (cs *ConstraintSystem) Mul(i1, i2 interface{}, in ...interface{}) Variable
 			switch t2 := _i2.(type) {
 			case Variable:
 				cs.completeDanglingVariable(&t2)
+				// if one of the operands is a constant, no constraint is recorded
+				if c, ok := cs.constantValue(t2); ok {
+					return cs.mulConstant(c, t1)
+				}
+				if c, ok := cs.constantValue(t1); ok {
+					return cs.mulConstant(c, t2)
+				}
 				_res = cs.newInternalVariable() // only in this case we record the constraint in the cs
-				cs.constraints = append(cs.constraints, newR1C(t1, t2, _res))
+				cs.addConstraint(newR1C(t1, t2, _res))
 				return _res
 			default:
 				_res = cs.mulConstant(t2, t1)
@@ -141,9 +147,9 @@ func (cs *ConstraintSystem) Mul(i1, i2 interface{}, in ...interface{}) Variable
 				_res = cs.mulConstant(t1, t2)
 				return _res
 			default:
-				n1 := FromInterface(t1)
-				n2 := FromInterface(t2)
-				n1.Mul(&n1, &n2)
+				n1, _ := cs.constantValue(t1)
+				n2, _ := cs.constantValue(t2)
+				n1.Mul(n1, n2)
 				_res = cs.Constant(n1)
 				return _res
 			}
@@ -165,10 +171,21 @@ func (cs *ConstraintSystem) Inverse(v Variable) Variable {
 
 	cs.completeDanglingVariable(&v)
 
+	// inverse of a constant, no constraint is recorded
+	if q := utils.FrModulus(cs.curveID); q != nil {
+		if c, ok := cs.constantValue(v); ok {
+			if c.Sign() == 0 {
+				cs.addUnsatisfiedError("[inverse] 0 has no inverse")
+				return cs.Constant(0)
+			}
+			return cs.Constant(c.ModInverse(c, q))
+		}
+	}
+
 	// allocate resulting variable
 	res := cs.newInternalVariable()
 
-	cs.constraints = append(cs.constraints, newR1C(v, res, cs.getOneVariable()))
+	cs.addConstraint(newR1C(v, res, cs.getOneVariable()))
 
 	return res
 }
@@ -176,6 +193,20 @@ func (cs *ConstraintSystem) Inverse(v Variable) Variable {
 // Div returns res = i1 / i2
 func (cs *ConstraintSystem) Div(i1, i2 interface{}) Variable {
 
+	// division between constants, no constraint is recorded
+	if q := utils.FrModulus(cs.curveID); q != nil {
+		c1, ok1 := cs.constantValue(i1)
+		c2, ok2 := cs.constantValue(i2)
+		if ok1 && ok2 {
+			if c2.Sign() == 0 {
+				cs.addUnsatisfiedError("[div] %s / 0, division by 0", c1.String())
+				return cs.Constant(0)
+			}
+			c2.ModInverse(c2, q).Mul(c2, c1).Mod(c2, q)
+			return cs.Constant(c2)
+		}
+	}
+
 	// allocate resulting variable
 	res := cs.newInternalVariable()
 
@@ -186,27 +217,142 @@ func (cs *ConstraintSystem) Div(i1, i2 interface{}) Variable {
 		switch t2 := i2.(type) {
 		case Variable:
 			cs.completeDanglingVariable(&t2)
-			cs.constraints = append(cs.constraints, newR1C(t2, res, t1))
+			cs.addConstraint(newR1C(t2, res, t1))
 		default:
 			tmp := cs.Constant(t2)
-			cs.constraints = append(cs.constraints, newR1C(tmp, res, t1))
+			cs.addConstraint(newR1C(tmp, res, t1))
 		}
 	default:
 		switch t2 := i2.(type) {
 		case Variable:
 			cs.completeDanglingVariable(&t2)
 			tmp := cs.Constant(t1)
-			cs.constraints = append(cs.constraints, newR1C(t2, res, tmp))
+			cs.addConstraint(newR1C(t2, res, tmp))
 		default:
 			tmp1 := cs.Constant(t1)
 			tmp2 := cs.Constant(t2)
-			cs.constraints = append(cs.constraints, newR1C(tmp2, res, tmp1))
+			cs.addConstraint(newR1C(tmp2, res, tmp1))
 		}
 	}
 
 	return res
 }
 
+// DivMod returns the quotient and the remainder of the integer division of a by b
+//
+// a and b must fit on nbBits bits, and 2^(2*nbBits+1) must be smaller than the scalar field modulus
+// (otherwise Compile fails). a, b, the quotient and the remainder are range checked (see ToBinary),
+// the remainder is checked to be smaller than b (which can't be 0), and quotient * b + remainder == a.
+//
+// Without a curve (see Compile), the width of the operands is checked when the curve is set (see SetCurve).
+func (cs *ConstraintSystem) DivMod(a, b Variable, nbBits int) (quotient, remainder Variable) {
+
+	cs.completeDanglingVariable(&a)
+	cs.completeDanglingVariable(&b)
+
+	if q := utils.FrModulus(cs.curveID); q == nil {
+		// the width is checked when the constraint system is tied to a curve (see SetCurve)
+		if 2*nbBits+2 > cs.minModulusBits {
+			cs.minModulusBits = 2*nbBits + 2
+		}
+	} else if 2*nbBits+1 >= q.BitLen() {
+		cs.addError("[divMod] %d bits operands may overflow the scalar field", nbBits)
+		return cs.Constant(0), cs.Constant(0)
+	}
+
+	// division between constants, no constraint is recorded
+	if c1, ok := cs.constantValue(a); ok {
+		if c2, ok := cs.constantValue(b); ok {
+			if c1.BitLen() > nbBits || c2.BitLen() > nbBits {
+				cs.addUnsatisfiedError("[divMod] %s or %s doesn't fit on %d bits", c1.String(), c2.String(), nbBits)
+				return cs.Constant(0), cs.Constant(0)
+			}
+			if c2.Sign() == 0 {
+				cs.addUnsatisfiedError("[divMod] %s / 0, division by 0", c1.String())
+				return cs.Constant(0), cs.Constant(0)
+			}
+			var q, r big.Int
+			q.QuoRem(c1, c2, &r)
+			return cs.Constant(q), cs.Constant(r)
+		}
+	}
+
+	// the quotient and the remainder are computed by the solver, and constrained below
+	quotient = cs.NewHint(hint.IntDiv, a, b)
+	remainder = cs.NewHint(hint.IntMod, a, b)
+
+	cs.ToBinary(quotient, nbBits)
+	cs.ToBinary(remainder, nbBits)
+	cs.ToBinary(b, nbBits)
+	cs.ToBinary(a, nbBits)
+
+	// remainder < b, which ensures b != 0
+	cs.AssertIsEqual(cs.isLess(remainder, b, nbBits), 1)
+
+	// the operands are small enough for quotient * b + remainder not to wrap around the modulus
+	cs.AssertIsEqual(cs.Add(cs.Mul(quotient, b), remainder), a)
+
+	return quotient, remainder
+}
+
+// Mod returns a mod m, where a is seen as an integer in [0, r), r being the scalar field modulus,
+// and m is a positive constant (see FromInterface)
+//
+// The quotient and the remainder of the division of a by m are range checked, so that
+// quotient * m + remainder == a holds over the integers.
+func (cs *ConstraintSystem) Mod(a Variable, m interface{}) Variable {
+
+	cs.completeDanglingVariable(&a)
+
+	c := FromInterface(m)
+	if c.Sign() <= 0 {
+		panic("Mod: modulus " + c.String() + " is not positive")
+	}
+	q := utils.FrModulus(cs.curveID)
+	if q == nil {
+		cs.addError("[mod] %w", ErrUnknownCurve)
+		return cs.Constant(0)
+	}
+
+	// a is a constant, no constraint is recorded
+	if v, ok := cs.constantValue(a); ok {
+		var res big.Int
+		return cs.Constant(res.Mod(v, &c))
+	}
+
+	if c.Cmp(bOne) == 0 {
+		return cs.Constant(0)
+	}
+
+	// r - 1 = qMax * m + rMax, if m >= r then a mod m == a
+	var qMax, rMax big.Int
+	qMax.QuoRem(new(big.Int).Sub(q, bOne), &c, &rMax)
+	if qMax.Sign() == 0 {
+		return a
+	}
+
+	// the quotient and the remainder are computed by the solver, and constrained below
+	quotient := cs.NewHint(hint.IntDiv, a, c)
+	remainder := cs.NewHint(hint.IntMod, a, c)
+
+	quotientBits := cs.ToBinary(quotient, qMax.BitLen())
+	remainderBits := cs.ToBinary(remainder, new(big.Int).Sub(&c, bOne).BitLen())
+
+	// remainder < m
+	cs.AssertIsEqual(cs.isLessOrEqualConstant(remainderBits, new(big.Int).Sub(&c, bOne)), 1)
+
+	// quotient * m + remainder <= r - 1, that is
+	// quotient <= qMax, and remainder <= rMax if quotient == qMax
+	cs.AssertIsEqual(cs.isLessOrEqualConstant(quotientBits, &qMax), 1)
+	quotientIsMax := cs.Sub(1, cs.isLessOrEqualConstant(quotientBits, new(big.Int).Sub(&qMax, bOne)))
+	remainderIsTooLarge := cs.Sub(1, cs.isLessOrEqualConstant(remainderBits, &rMax))
+	cs.AssertIsEqual(cs.Mul(quotientIsMax, remainderIsTooLarge), 0)
+
+	cs.AssertIsEqual(cs.Add(cs.Mul(quotient, c), remainder), a)
+
+	return remainder
+}
+
 // Xor compute the XOR between two variables
 func (cs *ConstraintSystem) Xor(a, b Variable) Variable {
 
@@ -216,12 +362,20 @@ func (cs *ConstraintSystem) Xor(a, b Variable) Variable {
 	cs.AssertIsBoolean(a)
 	cs.AssertIsBoolean(b)
 
+	// a and b are constants, no constraint is recorded
+	if c1, ok := cs.constantValue(a); ok {
+		if c2, ok := cs.constantValue(b); ok {
+			return cs.Constant(new(big.Int).Xor(c1, c2))
+		}
+	}
+
 	res := cs.newInternalVariable()
 	v1 := cs.Mul(2, a)   // no constraint recorded
 	v2 := cs.Add(a, b)   // no constraint recorded
 	v2 = cs.Sub(v2, res) // no constraint recorded
 
-	cs.constraints = append(cs.constraints, newR1C(v1, b, v2))
+	cs.addConstraint(newR1C(v1, b, v2))
+	cs.markBoolean(res)
 
 	return res
 }
@@ -235,11 +389,19 @@ func (cs *ConstraintSystem) Or(a, b Variable) Variable {
 	cs.AssertIsBoolean(a)
 	cs.AssertIsBoolean(b)
 
+	// a and b are constants, no constraint is recorded
+	if c1, ok := cs.constantValue(a); ok {
+		if c2, ok := cs.constantValue(b); ok {
+			return cs.Constant(new(big.Int).Or(c1, c2))
+		}
+	}
+
 	res := cs.newInternalVariable()
 	v1 := cs.Sub(1, a)
 	v2 := cs.Sub(res, a)
 
-	cs.constraints = append(cs.constraints, newR1C(b, v1, v2))
+	cs.addConstraint(newR1C(b, v1, v2))
+	cs.markBoolean(res)
 
 	return res
 }
@@ -254,6 +416,7 @@ func (cs *ConstraintSystem) And(a, b Variable) Variable {
 	cs.AssertIsBoolean(b)
 
 	res := cs.Mul(a, b)
+	cs.markBoolean(res)
 
 	return res
 }
@@ -261,20 +424,24 @@ func (cs *ConstraintSystem) And(a, b Variable) Variable {
 // IsZero returns 1 if a is zero, 0 otherwise
 func (cs *ConstraintSystem) IsZero(a Variable, id ecc.ID) Variable {
 
-	var expo big.Int
-	switch id {
-	case ecc.BN254:
-		expo.Set(frbn254.Modulus())
-	case ecc.BLS12_381:
-		expo.Set(frbls12381.Modulus())
-	case ecc.BLS12_377:
-		expo.Set(frbls12377.Modulus())
-	case ecc.BW6_761:
-		expo.Set(frbw6761.Modulus())
-	default:
-		panic("not implemented")
+	cs.completeDanglingVariable(&a)
+
+	// a is a constant, no constraint is recorded
+	if c, ok := cs.constantValue(a); ok {
+		if c.Sign() == 0 {
+			return cs.Constant(1)
+		}
+		return cs.Constant(0)
 	}
 
+	q := utils.FrModulus(id)
+	if q == nil {
+		cs.addError("[isZero] %w", ErrUnknownCurve)
+		return cs.Constant(0)
+	}
+	var expo big.Int
+	expo.Set(q)
+
 	res := cs.Constant(1)
 	expoBytes := expo.Bytes()
 	nbBits := len(expoBytes) * 8
@@ -285,10 +452,148 @@ func (cs *ConstraintSystem) IsZero(a Variable, id ecc.ID) Variable {
 		}
 	}
 	res = cs.Mul(res, res) // final squaring
+	cs.markBoolean(res)    // a^(q-1) is 0 or 1
 	res = cs.Sub(1, res)
 	return res
 }
 
+// IsEqual returns 1 if i1 == i2, 0 otherwise
+//
+// i1 and i2 can be Variables or constants (see FromInterface)
+func (cs *ConstraintSystem) IsEqual(i1, i2 interface{}) Variable {
+
+	d := cs.Sub(i1, i2) // no constraint is recorded
+
+	// i1 and i2 are constants, no constraint is recorded
+	if c, ok := cs.constantValue(d); ok {
+		if c.Sign() == 0 {
+			return cs.Constant(1)
+		}
+		return cs.Constant(0)
+	}
+
+	// res = 1 - d * d^-1 (0^-1 is set to 0 by the hint), and d * res == 0 ensures
+	// the hint can't set res to 0 when d == 0
+	inv := cs.NewHint(hint.InvZero, d)
+	m := cs.Mul(d, inv)
+	cs.markBoolean(m) // d * (1 - m) == 0 ensures m is 0 or 1
+	res := cs.Sub(1, m)
+
+	debugInfo := logEntry{
+		format:    "error IsEqual",
+		toResolve: nil,
+	}
+	stack := cs.getCallStack()
+	for i := 0; i < len(stack); i++ {
+		debugInfo.format += "\n" + stack[i]
+	}
+
+	cs.addAssertion(newR1C(d, res, cs.Constant(0)), debugInfo)
+
+	return res
+}
+
+// IsLessOrEqual returns 1 if i1 <= i2, 0 otherwise
+//
+// i1 and i2 can be Variables or constants (see FromInterface), they are range checked to fit on
+// nbBits bits (see ToBinary). 2^(nbBits+1) must be smaller than the scalar field modulus.
+func (cs *ConstraintSystem) IsLessOrEqual(i1, i2 interface{}, nbBits int) Variable {
+	cs.ToBinary(cs.Constant(i1), nbBits)
+	cs.ToBinary(cs.Constant(i2), nbBits)
+	return cs.isLessOrEqual(i1, i2, nbBits)
+}
+
+// IsLess returns 1 if i1 < i2, 0 otherwise
+//
+// i1 and i2 are range checked to fit on nbBits bits (see IsLessOrEqual)
+func (cs *ConstraintSystem) IsLess(i1, i2 interface{}, nbBits int) Variable {
+	cs.ToBinary(cs.Constant(i1), nbBits)
+	cs.ToBinary(cs.Constant(i2), nbBits)
+	return cs.isLess(i1, i2, nbBits)
+}
+
+// Cmp returns 1 if i1 > i2, 0 if i1 == i2, -1 if i1 < i2
+//
+// i1 and i2 are range checked to fit on nbBits bits (see IsLessOrEqual)
+func (cs *ConstraintSystem) Cmp(i1, i2 interface{}, nbBits int) Variable {
+	cs.ToBinary(cs.Constant(i1), nbBits)
+	cs.ToBinary(cs.Constant(i2), nbBits)
+	return cs.Sub(cs.isLessOrEqual(i2, i1, nbBits), cs.isLessOrEqual(i1, i2, nbBits))
+}
+
+// isLessOrEqual is IsLessOrEqual without the range checks of i1 and i2, which must be
+// enforced by the caller: otherwise the result is meaningless
+func (cs *ConstraintSystem) isLessOrEqual(i1, i2 interface{}, nbBits int) Variable {
+
+	// d = i2 - i1 + 2^nbBits is in [1, 2^(nbBits+1)), its most significant bit is set iff i1 <= i2
+	var offset big.Int
+	offset.Lsh(bOne, uint(nbBits))
+	d := cs.Add(cs.Sub(i2, i1), offset) // no constraint is recorded
+
+	bits := cs.ToBinary(d, nbBits+1)
+
+	return bits[nbBits]
+}
+
+// isLess is IsLess without the range checks of i1 and i2 (see isLessOrEqual)
+func (cs *ConstraintSystem) isLess(i1, i2 interface{}, nbBits int) Variable {
+	return cs.Sub(1, cs.isLessOrEqual(i2, i1, nbBits))
+}
+
+// Exp returns res = base^exponent
+//
+// exponent is decomposed on nbBits bits (see ToBinary), and base^exponent is computed by square-and-multiply,
+// from the most significant bit: 3 constraints per bit, in addition to the decomposition.
+func (cs *ConstraintSystem) Exp(base, exponent Variable, nbBits int) Variable {
+
+	bits := cs.ToBinary(exponent, nbBits)
+
+	// square and multiply from the most significant bit: res is the constant 1 on the first bit,
+	// so the two multiplications record no constraint there (only the selection does)
+	res := cs.Constant(1)
+	for i := len(bits) - 1; i >= 0; i-- {
+		res = cs.Mul(res, res)
+		res = cs.Mul(res, cs.Select(bits[i], base, 1))
+	}
+
+	return res
+}
+
+// ExpConstant returns res = base^exponent, where exponent is a non-negative constant (see FromInterface)
+//
+// base^exponent is computed following an addition chain of exponent: each term of the chain costs one constraint.
+// The shortest chain is used for small exponents, larger exponents use a sliding window chain.
+func (cs *ConstraintSystem) ExpConstant(base Variable, exponent interface{}) Variable {
+
+	cs.completeDanglingVariable(&base)
+
+	e := FromInterface(exponent)
+	if e.Sign() < 0 {
+		panic("ExpConstant: negative exponent " + e.String())
+	}
+	if e.Sign() == 0 {
+		return cs.Constant(1)
+	}
+
+	// base is a constant, no constraint is recorded
+	if c, ok := cs.constantValue(base); ok {
+		q := utils.FrModulus(cs.curveID)
+		if q == nil {
+			cs.addError("[expConstant] %w", ErrUnknownCurve)
+			return cs.Constant(0)
+		}
+		var res big.Int
+		return cs.Constant(res.Exp(c, &e, q))
+	}
+
+	terms := []Variable{base}
+	for _, step := range newAdditionChain(&e) {
+		terms = append(terms, cs.Mul(terms[step[0]], terms[step[1]]))
+	}
+
+	return terms[len(terms)-1]
+}
+
 // ToBinary unpacks a variable in binary, n is the number of bits of the variable
 //
 // The result in in little endian (first bit= lsb)
@@ -296,6 +601,18 @@ func (cs *ConstraintSystem) ToBinary(a Variable, nbBits int) []Variable {
 
 	cs.completeDanglingVariable(&a)
 
+	// decomposition of a constant, no constraint is recorded
+	if c, ok := cs.constantValue(a); ok {
+		if c.BitLen() > nbBits {
+			cs.addUnsatisfiedError("[toBinary] %s doesn't fit on %d bits", c.String(), nbBits)
+		}
+		res := make([]Variable, nbBits)
+		for i := 0; i < nbBits; i++ {
+			res[i] = cs.Constant(int(c.Bit(i)))
+		}
+		return res
+	}
+
 	// allocate the resulting variables
 	res := make([]Variable, nbBits)
 	for i := 0; i < nbBits; i++ {
@@ -318,7 +635,7 @@ func (cs *ConstraintSystem) ToBinary(a Variable, nbBits int) []Variable {
 
 	one := cs.getOneVariable()
 
-	cs.constraints = append(cs.constraints, newR1C(v, one, a, compiled.BinaryDec))
+	cs.addConstraint(newR1C(v, one, a, compiled.BinaryDec))
 
 	return res
 
@@ -361,6 +678,22 @@ func (cs *ConstraintSystem) Select(b Variable, i1, i2 interface{}) Variable {
 	// ensures that b is boolean
 	cs.AssertIsBoolean(b)
 
+	// b is a constant, no constraint is recorded
+	if c, ok := cs.constantValue(b); ok {
+		if c.Sign() == 0 {
+			return cs.Constant(i2)
+		}
+		return cs.Constant(i1)
+	}
+
+	// constant Variables are handled as constants
+	if c, ok := cs.constantValue(i1); ok {
+		i1 = c
+	}
+	if c, ok := cs.constantValue(i2); ok {
+		i2 = c
+	}
+
 	var res Variable
 
 	switch t1 := i1.(type) {
@@ -370,7 +703,7 @@ func (cs *ConstraintSystem) Select(b Variable, i1, i2 interface{}) Variable {
 		v := cs.Sub(t1, i2)  // no constraint is recorded
 		w := cs.Sub(res, i2) // no constraint is recorded
 		//cs.Println("u-v: ", v)
-		cs.constraints = append(cs.constraints, newR1C(b, v, w))
+		cs.addConstraint(newR1C(b, v, w))
 		return res
 	default:
 		switch t2 := i2.(type) {
@@ -379,7 +712,7 @@ func (cs *ConstraintSystem) Select(b Variable, i1, i2 interface{}) Variable {
 			res = cs.newInternalVariable()
 			v := cs.Sub(t1, t2)  // no constraint is recorded
 			w := cs.Sub(res, t2) // no constraint is recorded
-			cs.constraints = append(cs.constraints, newR1C(b, v, w))
+			cs.addConstraint(newR1C(b, v, w))
 			return res
 		default:
 			// in this case, no constraint is recorded
@@ -393,6 +726,113 @@ func (cs *ConstraintSystem) Select(b Variable, i1, i2 interface{}) Variable {
 	}
 }
 
+// Lookup2 performs a 2-bit lookup between i0, i1, i2, i3 based on bits b0
+// and b1. Returns i0 if b0=b1=0, i1 if b0=1 and b1=0, i2 if b0=0 and b1=1
+// and i3 if b0=b1=1.
+func (cs *ConstraintSystem) Lookup2(b0, b1 Variable, i0, i1, i2, i3 interface{}) Variable {
+
+	cs.completeDanglingVariable(&b0)
+	cs.completeDanglingVariable(&b1)
+
+	// ensures that the selectors are boolean
+	cs.AssertIsBoolean(b0)
+	cs.AssertIsBoolean(b1)
+
+	// the lookup is done with three constraints:
+	//    (1) (i3 - i2 - i1 + i0) * b1 = tmp1 - i1 + i0
+	//    (2) tmp1 * b0 = tmp2
+	//    (3) (i2 - i0) * b1 = res - tmp2 - i0
+	// if the inputs are constants, (1) and (3) are linear expressions (no constraint is recorded)
+	tmp1 := cs.Add(cs.Mul(cs.Sub(cs.Add(i3, i0), cs.Add(i2, i1)), b1), i1)
+	tmp1 = cs.Sub(tmp1, i0)
+	tmp2 := cs.Mul(tmp1, b0)
+	res := cs.Add(cs.Mul(cs.Sub(i2, i0), b1), tmp2, i0)
+
+	return res
+}
+
+// Mux selects values[sel]
+//
+// sel is decomposed in binary and the values are selected with a tree of 2-bit lookups (see Lookup2),
+// Mux fails if sel >= len(values).
+func (cs *ConstraintSystem) Mux(sel Variable, values ...interface{}) Variable {
+
+	cs.completeDanglingVariable(&sel)
+
+	if len(values) == 0 {
+		panic("Mux: no values to select from")
+	}
+	if len(values) == 1 {
+		cs.AssertIsEqual(sel, 0)
+		return cs.Constant(values[0])
+	}
+
+	nbBits := bits.Len(uint(len(values) - 1))
+	selBits := cs.ToBinary(sel, nbBits)
+
+	// if len(values) is not a power of 2, the decomposition doesn't ensure sel < len(values)
+	if len(values) != 1<<nbBits {
+		cs.AssertIsEqual(cs.isLessOrEqual(sel, len(values)-1, nbBits), 1)
+	}
+
+	// pad the values up to 2^nbBits, the padding values can't be selected
+	level := make([]interface{}, 1<<nbBits)
+	for i := 0; i < len(level); i++ {
+		if i < len(values) {
+			level[i] = values[i]
+		} else {
+			level[i] = values[len(values)-1]
+		}
+	}
+
+	// each level of the tree consumes 2 bits of sel, starting from the lsb
+	for len(selBits) >= 2 {
+		next := make([]interface{}, len(level)/4)
+		for i := 0; i < len(next); i++ {
+			next[i] = cs.Lookup2(selBits[0], selBits[1], level[4*i], level[4*i+1], level[4*i+2], level[4*i+3])
+		}
+		level = next
+		selBits = selBits[2:]
+	}
+	if len(selBits) == 1 {
+		return cs.Select(selBits[0], level[1], level[0])
+	}
+
+	return cs.Constant(level[0])
+}
+
+// ArrayGet returns arr[index], and fails if index >= len(arr) (see Mux)
+func (cs *ConstraintSystem) ArrayGet(arr []Variable, index Variable) Variable {
+	values := make([]interface{}, len(arr))
+	for i := 0; i < len(arr); i++ {
+		values[i] = arr[i]
+	}
+	return cs.Mux(index, values...)
+}
+
+// NewHint initializes an internal variable whose value will be evaluated by the solver,
+// calling the hint function f on the values of inputs (see package backend/hint)
+//
+// No constraint is recorded: it is the developer responsability to constrain the returned variable.
+//
+// inputs can be Variables or constants (see FromInterface)
+func (cs *ConstraintSystem) NewHint(f hint.Function, inputs ...interface{}) Variable {
+
+	h := hintEntry{nbConstraints: len(cs.constraints)}
+	h.ID = hint.Register(f)
+	h.Inputs = make([]compiled.LinearExpression, len(inputs))
+	for i := 0; i < len(inputs); i++ {
+		v := cs.Constant(inputs[i]) // no constraint is recorded
+		h.Inputs[i] = v.linExp.Clone()
+	}
+
+	res := cs.newInternalVariable()
+	h.WireID = res.id
+	cs.hints = append(cs.hints, h)
+
+	return res
+}
+
 // Constant will return (and allocate if neccesary) a constant Variable
 //
 // input can be a Variable or must be convertible to big.Int (see FromInterface)
@@ -436,6 +876,16 @@ func (cs *ConstraintSystem) AssertIsEqual(i1, i2 interface{}) {
 	// set R = 1
 	// set O = i2
 
+	// assertion between constants, checked when the circuit is compiled (no constraint is recorded)
+	if c1, ok := cs.constantValue(i1); ok {
+		if c2, ok := cs.constantValue(i2); ok {
+			if c1.Cmp(c2) != 0 {
+				cs.addUnsatisfiedError("[assertIsEqual] %s == %s", c1.String(), c2.String())
+			}
+			return
+		}
+	}
+
 	// we don't do just "cs.Sub(i1,i2)" to allow proper logging
 	debugInfo := logEntry{}
 
@@ -452,6 +902,10 @@ func (cs *ConstraintSystem) AssertIsEqual(i1, i2 interface{}) {
 	debugInfo.format += rhs.format
 	debugInfo.toResolve = append(debugInfo.toResolve, rhs.toResolve...)
 	debugInfo.format += "]"
+	stack := cs.getCallStack()
+	for i := 0; i < len(stack); i++ {
+		debugInfo.format += "\n" + stack[i]
+	}
 
 	cs.addAssertion(newR1C(l, r, o), debugInfo)
 }
@@ -461,25 +915,31 @@ func (cs *ConstraintSystem) AssertIsBoolean(v Variable) {
 
 	cs.completeDanglingVariable(&v)
 
-	if v.isBoolean {
+	if cs.isBoolean(v) {
+		return
+	}
+
+	// v is a constant different from 0 and 1
+	if c, ok := cs.constantValue(v); ok {
+		cs.addUnsatisfiedError("[assertIsBoolean] %s == (0|1)", c.String())
 		return
 	}
 
 	_v := cs.Sub(1, v)  // no variable is recorded in the cs
 	o := cs.Constant(0) // no variable is recorded in the cs
-	v.isBoolean = true
+	cs.markBoolean(v)
 
 	// prepare debug info to be displayed in case the constraint is not solved
 	// debugInfo := logEntry{
 	// 	format:    fmt.Sprintf("%%s == (0 or 1)"),
 	// 	toResolve: []compiled.Term{compiled.Pack(v.id, 0, v.visibility)},
 	// }
-	// stack := getCallStack()
+	// stack := cs.getCallStack()
 	debugInfo := logEntry{
 		format:    "error AssertIsBoolean",
 		toResolve: nil,
 	}
-	stack := getCallStack()
+	stack := cs.getCallStack()
 	for i := 0; i < len(stack); i++ {
 		debugInfo.format += "\n" + stack[i]
 	}
@@ -497,6 +957,16 @@ func (cs *ConstraintSystem) AssertIsLessOrEqual(v Variable, bound interface{}) {
 
 	cs.completeDanglingVariable(&v)
 
+	// assertion between constants, checked when the circuit is compiled (no constraint is recorded)
+	if c1, ok := cs.constantValue(v); ok {
+		if c2, ok := cs.constantValue(bound); ok {
+			if c1.Cmp(c2) > 0 {
+				cs.addUnsatisfiedError("[assertIsLessOrEqual] %s <= %s", c1.String(), c2.String())
+			}
+			return
+		}
+	}
+
 	switch b := bound.(type) {
 	case Variable:
 		cs.completeDanglingVariable(&b)
@@ -518,7 +988,7 @@ func (cs *ConstraintSystem) mustBeLessOrEqVar(w, bound Variable) {
 	copy(debugInfo.toResolve[:], dbgInfoW.toResolve)
 	copy(debugInfo.toResolve[len(dbgInfoW.toResolve):], dbgInfoBound.toResolve)
 
-	stack := getCallStack()
+	stack := cs.getCallStack()
 	for i := 0; i < len(stack); i++ {
 		debugInfo.format += "\n" + stack[i]
 	}
@@ -550,6 +1020,35 @@ func (cs *ConstraintSystem) mustBeLessOrEqVar(w, bound Variable) {
 		cs.addAssertion(newR1C(l, r, o), debugInfo)
 	}
 
+	// p[0] is not used
+	cs.markUnused(p[0])
+}
+
+// isLessOrEqualConstant returns 1 if the number whose binary decomposition (little endian) is bits
+// is smaller or equal to bound, 0 otherwise
+//
+// bits must be boolean (see ToBinary), one constraint is recorded per bit.
+func (cs *ConstraintSystem) isLessOrEqualConstant(bits []Variable, bound *big.Int) Variable {
+	if bound.Sign() < 0 {
+		return cs.Constant(0)
+	}
+	if bound.BitLen() > len(bits) {
+		return cs.Constant(1)
+	}
+
+	// res is 1 iff bits[:i] <= bound[:i], starting from the lsb
+	res := cs.Constant(1)
+	for i := 0; i < len(bits); i++ {
+		if bound.Bit(i) == 1 {
+			// bits[:i+1] <= bound[:i+1] iff bits[i] == 0 or bits[:i] <= bound[:i]
+			res = cs.Sub(1, cs.Mul(bits[i], cs.Sub(1, res)))
+		} else {
+			// bits[:i+1] <= bound[:i+1] iff bits[i] == 0 and bits[:i] <= bound[:i]
+			res = cs.Sub(res, cs.Mul(bits[i], res))
+		}
+	}
+
+	return res
 }
 
 func (cs *ConstraintSystem) mustBeLessOrEqCst(v Variable, bound big.Int) {
@@ -560,7 +1059,7 @@ func (cs *ConstraintSystem) mustBeLessOrEqCst(v Variable, bound big.Int) {
 	debugInfo.format = dbgInfoW.format + " <= " + bound.String()
 	debugInfo.toResolve = dbgInfoW.toResolve
 
-	stack := getCallStack()
+	stack := cs.getCallStack()
 	for i := 0; i < len(stack); i++ {
 		debugInfo.format += "\n" + stack[i]
 	}
@@ -601,6 +1100,9 @@ func (cs *ConstraintSystem) mustBeLessOrEqCst(v Variable, bound big.Int) {
 			}
 		}
 	}
+
+	// p[0] is not used
+	cs.markUnused(p[0])
 }
 
 // Println enables circuit debugging and behaves almost like fmt.Println()
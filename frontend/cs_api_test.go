@@ -363,7 +363,7 @@ func rfSelect() runfunc {
 	return res
 }
 
-var nsSelect = deltaState{1, 2, 3, 3, 4}
+var nsSelect = deltaState{1, 2, 3, 3, 1}
 
 // copy of variable
 func rfConstant() runfunc {
@@ -430,6 +430,39 @@ func rfIsEqual() runfunc {
 
 var nsIsEqual = deltaState{1, 1, 0, 0, 2}
 
+// comparison of 2 variables
+func rfIsLessOrEqual() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable()
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		b := systemUnderTest.(*ConstraintSystem).newSecretVariable()
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, b)
+
+		c := systemUnderTest.(*ConstraintSystem).IsLessOrEqual(a, b, 8)
+		iVariablesCreated = append(iVariablesCreated, c)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			compiled.BinaryDec}
+
+		return csRes
+	}
+	return res
+}
+
+var nsIsLessOrEqual = deltaState{1, 1, 25, 3, 25} // a and b are range checked on 8 bits, b - a + 2^8 is decomposed on 9 bits
+
 // packing from binary variables
 func rfFromBinary() runfunc {
 	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
@@ -495,11 +528,70 @@ func rfIsBoolean() runfunc {
 	return res
 }
 
-var nsIsBoolean = deltaState{1, 1, 0, 0, 4}
+var nsIsBoolean = deltaState{1, 1, 0, 0, 2}
+
+// a variable is less or equal than another variable
+func rfMustBeLessOrEqVar() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable()
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
 
-var nsMustBeLessOrEqVar = deltaState{1, 1, 1281, 771, 768}
+		bound := systemUnderTest.(*ConstraintSystem).newSecretVariable()
+		incVariableName()
+		sVariablesCreated = append(sVariablesCreated, bound)
+
+		systemUnderTest.(*ConstraintSystem).AssertIsLessOrEqual(a, bound)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			compiled.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
+
+var nsMustBeLessOrEqVar = deltaState{1, 1, 1278, 768, 768}
+
+// a variable is less or equal than a constant
+func rfMustBeLessOrEqConst() runfunc {
+	res := func(systemUnderTest commands.SystemUnderTest) commands.Result {
+
+		pVariablesCreated := make([]Variable, 0)
+		sVariablesCreated := make([]Variable, 0)
+		iVariablesCreated := make([]Variable, 0)
+
+		a := systemUnderTest.(*ConstraintSystem).newPublicVariable()
+		incVariableName()
+		pVariablesCreated = append(pVariablesCreated, a)
+
+		// bound = 2**256 - 1 - 2 (HW(bound) = 255)
+		var bound big.Int
+		bound.Lsh(bOne, 256).Sub(&bound, bOne).Sub(&bound, bTwo)
+		systemUnderTest.(*ConstraintSystem).AssertIsLessOrEqual(a, bound)
+
+		csRes := csResult{
+			systemUnderTest.(*ConstraintSystem),
+			pVariablesCreated,
+			sVariablesCreated,
+			iVariablesCreated,
+			compiled.SingleOutput}
+
+		return csRes
+	}
+	return res
+}
 
-var nsMustBeLessOrEqConst = csState{1, 0, 257, 2, 511} // nb internal variables: 256+HW(bound), nb constraints: 1+HW(bound), nb assertions: 256+HW(^bound)
+var nsMustBeLessOrEqConst = deltaState{1, 0, 510, 255, 257} // nb internal variables: 256+HW(bound)-1, nb constraints: HW(bound), nb assertions: 256+HW(^bound)
 
 // ------------------------------------------------------------------------------
 // build the next state function using the delta state
@@ -531,10 +623,11 @@ func TestAPI(t *testing.T) {
 		buildProtoCommands("Select 2 variables", rfSelect(), nextStateFunc(nsSelect)),
 		buildProtoCommands("Constant", rfConstant(), nextStateFunc(nsConstant)),
 		buildProtoCommands("IsEqual", rfIsEqual(), nextStateFunc(nsIsEqual)),
+		buildProtoCommands("IsLessOrEqual", rfIsLessOrEqual(), nextStateFunc(nsIsLessOrEqual)),
 		buildProtoCommands("FromBinary", rfFromBinary(), nextStateFunc(nsFromBinary)),
-		buildProtoCommands("IsBoolean", rfIsBoolean(), nextStateFunc(nsIsBoolean)), // TODO fix isBoolean to record if it was already boolean constrained
-		// buildProtoCommands("Must be less or eq var", rfMustBeLessOrEqVar(), nextStateFunc(nsMustBeLessOrEqVar)), // TODO restore once isBoolean is fixed
-		// buildProtoCommands("Must be less or eq const", rfMustBeLessOrEqConst(), nextStateFunc(nsMustBeLessOrEqConst)), // TODO idem
+		buildProtoCommands("IsBoolean", rfIsBoolean(), nextStateFunc(nsIsBoolean)),
+		buildProtoCommands("Must be less or eq var", rfMustBeLessOrEqVar(), nextStateFunc(nsMustBeLessOrEqVar)),
+		buildProtoCommands("Must be less or eq const", rfMustBeLessOrEqConst(), nextStateFunc(nsMustBeLessOrEqConst)),
 	}
 
 	// generate randomly a sequence of commands
@@ -573,7 +666,7 @@ type addCircuit struct {
 	A Variable
 }
 
-func (c *addCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *addCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	a := cs.Add(unsetVar, c.A)
 	cs.AssertIsEqual(a, 3)
@@ -584,7 +677,7 @@ type subCircuit struct {
 	A Variable
 }
 
-func (c *subCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *subCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	a := cs.Sub(unsetVar, c.A)
 	cs.AssertIsEqual(a, 3)
@@ -595,7 +688,7 @@ type mulCircuit struct {
 	A Variable
 }
 
-func (c *mulCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *mulCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.Mul(unsetVar, c.A)
 	return nil
@@ -605,7 +698,7 @@ type invCircuit struct {
 	A Variable
 }
 
-func (c *invCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *invCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.Inverse(unsetVar)
 	return nil
@@ -615,7 +708,7 @@ type divCircuit struct {
 	A Variable
 }
 
-func (c *divCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *divCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.Div(unsetVar, c.A)
 	return nil
@@ -625,7 +718,7 @@ type xorCircuit struct {
 	A Variable
 }
 
-func (c *xorCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *xorCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.Xor(unsetVar, c.A)
 	return nil
@@ -635,7 +728,7 @@ type toBinaryCircuit struct {
 	A Variable
 }
 
-func (c *toBinaryCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *toBinaryCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.ToBinary(unsetVar, 256)
 	return nil
@@ -645,7 +738,7 @@ type fromBinaryCircuit struct {
 	A Variable
 }
 
-func (c *fromBinaryCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *fromBinaryCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	a := cs.FromBinary(unsetVar)
 	cs.AssertIsEqual(a, 3)
@@ -656,7 +749,7 @@ type selectCircuit struct {
 	A Variable
 }
 
-func (c *selectCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *selectCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.Select(unsetVar, c.A, 1)
 	return nil
@@ -666,7 +759,7 @@ type isEqualCircuit struct {
 	A Variable
 }
 
-func (c *isEqualCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *isEqualCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.AssertIsEqual(unsetVar, c.A)
 	return nil
@@ -676,7 +769,7 @@ type isBooleanCircuit struct {
 	A Variable
 }
 
-func (c *isBooleanCircuit) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *isBooleanCircuit) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.AssertIsBoolean(unsetVar)
 	return nil
@@ -686,7 +779,7 @@ type isLessOrEq struct {
 	A Variable
 }
 
-func (c *isLessOrEq) Define(curveID ecc.ID, cs *ConstraintSystem) error {
+func (c *isLessOrEq) Define(curveID ecc.ID, cs API) error {
 	var unsetVar Variable
 	cs.AssertIsLessOrEqual(unsetVar, c.A)
 	return nil
@@ -0,0 +1,84 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontend
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	backend_bls12377 "github.com/consensys/gnark/internal/backend/bls12-377/cs"
+	backend_bls12381 "github.com/consensys/gnark/internal/backend/bls12-381/cs"
+	backend_bn254 "github.com/consensys/gnark/internal/backend/bn254/cs"
+	backend_bw6761 "github.com/consensys/gnark/internal/backend/bw6-761/cs"
+	"github.com/consensys/gnark/internal/backend/compiled"
+)
+
+// ReadConstraintSystem reads a compiled constraint system written by CompiledConstraintSystem.WriteTo
+//
+// The curve and the backend are read from the header of the serialized constraint system, and
+// the returned CompiledConstraintSystem has the matching concrete type (R1CS for GROTH16,
+// SparseR1CS for PLONK). A constraint system which is not tied to a curve (ecc.UNKNOWN) is read as
+// such, and may then be tied to a curve with SetCurve.
+func ReadConstraintSystem(r io.Reader) (CompiledConstraintSystem, error) {
+	var buf bytes.Buffer
+	header, err := compiled.ReadHeader(io.TeeReader(r, &buf))
+	if err != nil {
+		return nil, err
+	}
+
+	var ccs CompiledConstraintSystem
+	switch header.BackendID {
+	case backend.GROTH16:
+		switch header.CurveID {
+		case ecc.UNKNOWN:
+			ccs = &compiled.R1CS{}
+		case ecc.BN254:
+			ccs = &backend_bn254.R1CS{}
+		case ecc.BLS12_377:
+			ccs = &backend_bls12377.R1CS{}
+		case ecc.BLS12_381:
+			ccs = &backend_bls12381.R1CS{}
+		case ecc.BW6_761:
+			ccs = &backend_bw6761.R1CS{}
+		}
+	case backend.PLONK:
+		switch header.CurveID {
+		case ecc.UNKNOWN:
+			ccs = &compiled.SparseR1CS{}
+		case ecc.BN254:
+			ccs = &backend_bn254.SparseR1CS{}
+		case ecc.BLS12_377:
+			ccs = &backend_bls12377.SparseR1CS{}
+		case ecc.BLS12_381:
+			ccs = &backend_bls12381.SparseR1CS{}
+		case ecc.BW6_761:
+			ccs = &backend_bw6761.SparseR1CS{}
+		}
+	}
+	if ccs == nil {
+		return nil, fmt.Errorf("%w: unsupported curve %s or backend %s", compiled.ErrInvalidFormat, compiled.CurveName(header.CurveID), header.BackendID)
+	}
+
+	// the header is read again by ccs
+	if _, err := ccs.ReadFrom(io.MultiReader(&buf, r)); err != nil {
+		return nil, err
+	}
+	return ccs, nil
+}
@@ -0,0 +1,75 @@
+package frontend
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/internal/backend/compiled"
+)
+
+type readCircuit struct {
+	X, Y Variable
+	Z    Variable `gnark:",public"`
+}
+
+func (circuit *readCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.AssertIsEqual(cs.Mul(circuit.X, circuit.Y), circuit.Z)
+	return nil
+}
+
+func TestReadConstraintSystem(t *testing.T) {
+	for _, backendID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+		for _, curveID := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+			var circuit readCircuit
+			ccs, err := Compile(curveID, backendID, &circuit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if backendID == backend.GROTH16 {
+				ccs.SetLoggerOutput(nil) // the logger is not serialized
+			}
+
+			var buf bytes.Buffer
+			if _, err := ccs.WriteTo(&buf); err != nil {
+				t.Fatal(err)
+			}
+			reconstructed, err := ReadConstraintSystem(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reflect.TypeOf(reconstructed) != reflect.TypeOf(ccs) {
+				t.Fatalf("%s %s: expected %T, got %T", backendID, curveID, ccs, reconstructed)
+			}
+			if !reflect.DeepEqual(ccs, reconstructed) {
+				t.Fatalf("%s %s: round trip serialization failed", backendID, curveID)
+			}
+		}
+	}
+
+	// a constraint system which is not tied to a curve keeps its coefficients
+	for _, backendID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+		ccs, err := Compile(ecc.UNKNOWN, backendID, &curveIndependentCircuit{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if _, err := ccs.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		reconstructed, err := ReadConstraintSystem(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ccs, reconstructed) {
+			t.Fatalf("%s: round trip serialization failed", backendID)
+		}
+	}
+
+	if _, err := ReadConstraintSystem(bytes.NewReader([]byte("not a constraint system, but long enough to hold a header"))); !errors.Is(err, compiled.ErrInvalidFormat) {
+		t.Fatalf("expected an invalid format error, got %v", err)
+	}
+}
@@ -1,9 +1,13 @@
 package frontend
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/internal/backend/compiled"
 )
 
@@ -107,3 +111,168 @@ func TestFindUnsolvedVariable(t *testing.T) {
 		solvedVariables[i] = true
 	}
 }
+
+func TestBooleanTracking(t *testing.T) {
+
+	cs := newConstraintSystem()
+	x := cs.newSecretVariable()
+	y := cs.newSecretVariable()
+
+	// outputs of Xor, Or, And, ToBinary, IsZero, and their negation, are known to be boolean
+	booleans := []Variable{
+		cs.Xor(x, y),
+		cs.Or(x, y),
+		cs.And(x, y),
+		cs.IsZero(x, ecc.BN254),
+		cs.Sub(1, cs.Xor(x, y)),
+	}
+	booleans = append(booleans, cs.ToBinary(x, 8)...)
+
+	nbAssertions := len(cs.assertions)
+	for i := 0; i < len(booleans); i++ {
+		cs.AssertIsBoolean(booleans[i])
+	}
+	cs.AssertIsBoolean(x)
+	cs.AssertIsBoolean(cs.Constant(1))
+	if len(cs.assertions) != nbAssertions {
+		t.Fatal("boolean variables should not be constrained again")
+	}
+
+	cs.AssertIsBoolean(cs.Mul(x, y))
+	cs.AssertIsBoolean(cs.Add(x, y))
+	if len(cs.assertions) != nbAssertions+2 {
+		t.Fatal("non boolean variables should be constrained")
+	}
+}
+
+type constantCircuit struct {
+	X Variable
+}
+
+func (circuit *constantCircuit) Define(curveID ecc.ID, cs API) error {
+	a := cs.Constant(3)
+	b := cs.Mul(a, cs.Constant(5))
+	c := cs.Div(b, cs.Inverse(a))
+	bits := cs.ToBinary(c, 8)
+	d := cs.Xor(bits[0], cs.IsZero(cs.Sub(c, 45), curveID))
+	e := cs.Select(d, cs.FromBinary(bits...), 0)
+	cs.AssertIsEqual(e, 0)
+	cs.AssertIsBoolean(d)
+	cs.AssertIsLessOrEqual(c, 200)
+
+	// a constant times a variable is a linear expression
+	cs.AssertIsEqual(cs.Mul(circuit.X, c), 135)
+	return nil
+}
+
+type wrongConstantCircuit struct {
+	X Variable
+}
+
+func (circuit *wrongConstantCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.AssertIsEqual(cs.Mul(circuit.X, 2), 6)
+	cs.AssertIsEqual(cs.Mul(cs.Constant(3), 2), 7)
+	return nil
+}
+
+func TestConstantFolding(t *testing.T) {
+
+	var circuit constantCircuit
+	cs, err := buildCS(ecc.BN254, &circuit, compileConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cs.constraints) != 0 || len(cs.internal.variables) != 0 || len(cs.assertions) != 1 {
+		t.Fatal("operations on constants should not record constraints")
+	}
+
+	var wrongCircuit wrongConstantCircuit
+	if _, err := buildCS(ecc.BN254, &wrongCircuit, compileConfig{}); !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+		t.Fatal("assertion between constants should be checked at compile time")
+	}
+}
+
+type outputCircuit struct {
+	X Variable
+	Y Variable `gnark:",output"`
+	Z Variable `gnark:",output"`
+}
+
+func (circuit *outputCircuit) Define(curveID ecc.ID, cs API) error {
+	circuit.Y = cs.Mul(circuit.X, circuit.X)
+	circuit.Z = cs.Add(circuit.Y, 1)
+	return nil
+}
+
+type unassignedOutputCircuit struct {
+	X Variable
+	Y Variable `gnark:",output"`
+}
+
+func (circuit *unassignedOutputCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.AssertIsEqual(circuit.X, 3)
+	return nil
+}
+
+func TestOutputs(t *testing.T) {
+
+	var circuit outputCircuit
+	cs, err := buildCS(ecc.BN254, &circuit, compileConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cs.public.names) != 3 || len(cs.outputs) != 2 { // ONE_WIRE, Y, Z
+		t.Fatal("outputs should be public variables")
+	}
+	// Y is held by the result of Mul, Z needs a new internal variable
+	if len(cs.constraints) != 2 || len(cs.assertions) != 2 {
+		t.Fatal("each output should be bound to its value by an assertion")
+	}
+	if circuit.Y.id != cs.outputs[0].wire.id || circuit.Z.id != cs.outputs[1].wire.id {
+		t.Fatal("output fields should be restored to the public variables")
+	}
+
+	var unassigned unassignedOutputCircuit
+	if _, err := buildCS(ecc.BN254, &unassigned, compileConfig{}); err == nil {
+		t.Fatal("an output not assigned in Define should be an error")
+	}
+
+	// Define records 1 constraint, binding the outputs records 3 more
+	if _, err := Compile(ecc.BN254, backend.GROTH16, &outputCircuit{}, WithMaxConstraints(2)); !errors.Is(err, ErrMaxConstraints) {
+		t.Fatal("expected ErrMaxConstraints, got", err)
+	}
+}
+
+type transfer struct {
+	Amount, Nonce Variable
+}
+
+type namesCircuit struct {
+	Transfers [2]transfer `gnark:",public"`
+	Root      Variable    `gnark:",public"`
+	Key       Variable
+}
+
+func (circuit *namesCircuit) Define(curveID ecc.ID, cs API) error {
+	sum := cs.Add(circuit.Transfers[0].Amount, circuit.Transfers[1].Amount, circuit.Transfers[0].Nonce, circuit.Transfers[1].Nonce)
+	cs.AssertIsEqual(cs.Mul(sum, circuit.Key), circuit.Root)
+	return nil
+}
+
+func TestInputNames(t *testing.T) {
+	public := []string{"Transfers_0_Amount", "Transfers_0_Nonce", "Transfers_1_Amount", "Transfers_1_Nonce", "Root"}
+	secret := []string{"Key"}
+	for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+		var circuit namesCircuit
+		ccs, err := Compile(ecc.BN254, zkpID, &circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(ccs.PublicInputNames(), public) {
+			t.Fatalf("wrong public input names: %v", ccs.PublicInputNames())
+		}
+		if !reflect.DeepEqual(ccs.SecretInputNames(), secret) {
+			t.Fatalf("wrong secret input names: %v", ccs.SecretInputNames())
+		}
+	}
+}
@@ -2,6 +2,7 @@ package frontend
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/internal/backend/compiled"
@@ -22,11 +23,14 @@ func (cs *ConstraintSystem) toR1CS(curveID ecc.ID) (CompiledConstraintSystem, er
 		NbInternalVariables: len(cs.internal.variables),
 		NbPublicVariables:   len(cs.public.variables),
 		NbSecretVariables:   len(cs.secret.variables),
+		PublicNames:         cs.publicInputNames(),
+		SecretNames:         cs.secretInputNames(),
 		NbConstraints:       len(cs.constraints) + len(cs.assertions),
 		NbCOConstraints:     len(cs.constraints),
 		Constraints:         make([]compiled.R1C, len(cs.constraints)+len(cs.assertions)),
 		Logs:                make([]compiled.LogEntry, len(cs.logs)),
 		DebugInfo:           make([]compiled.LogEntry, len(cs.debugInfo)),
+		MinModulusBits:      cs.minModulusBits,
 	}
 
 	// computational constraints (= gates)
@@ -67,6 +71,32 @@ func (cs *ConstraintSystem) toR1CS(curveID ecc.ID) (CompiledConstraintSystem, er
 		}
 	}
 
+	// offset ids in the hints
+	res.Hints = make([]compiled.Hint, len(cs.hints))
+	for i := 0; i < len(cs.hints); i++ {
+		h := compiled.Hint{
+			ID:     cs.hints[i].ID,
+			WireID: cs.hints[i].WireID + len(cs.public.variables) + len(cs.secret.variables),
+			Inputs: make([]compiled.LinearExpression, len(cs.hints[i].Inputs)),
+		}
+		for j := 0; j < len(h.Inputs); j++ {
+			h.Inputs[j] = cs.hints[i].Inputs[j].Clone()
+			if err := offsetIDs(h.Inputs[j]); err != nil {
+				return &res, err
+			}
+		}
+		res.Hints[i] = h
+	}
+
+	// offset ids in the public outputs
+	res.Outputs = make([]compiled.PublicOutput, len(cs.outputs))
+	for i := 0; i < len(cs.outputs); i++ {
+		res.Outputs[i] = compiled.PublicOutput{
+			WireID:  cs.outputs[i].wire.id,
+			ValueID: cs.outputs[i].value + len(cs.public.variables) + len(cs.secret.variables),
+		}
+	}
+
 	// we need to offset the ids in logs too
 	for i := 0; i < len(cs.logs); i++ {
 		entry := compiled.LogEntry{
@@ -113,17 +143,25 @@ func (cs *ConstraintSystem) toR1CS(curveID ecc.ID) (CompiledConstraintSystem, er
 		res.DebugInfo[i] = entry
 	}
 
+	if curveID == ecc.UNKNOWN {
+		// the coefficients are reduced once the R1CS is tied to a curve (see SetCurve)
+		res.Coeffs = cs.coeffs
+		return &res, nil
+	}
+	return typedR1CS(curveID, res, cs.coeffs), nil
+}
+
+// typedR1CS returns the R1CS of the curve, with the coefficients reduced in its scalar field
+func typedR1CS(curveID ecc.ID, r1cs compiled.R1CS, coeffs []big.Int) CompiledConstraintSystem {
 	switch curveID {
 	case ecc.BLS12_377:
-		return bls12377r1cs.NewR1CS(res, cs.coeffs), nil
+		return bls12377r1cs.NewR1CS(r1cs, coeffs)
 	case ecc.BLS12_381:
-		return bls12381r1cs.NewR1CS(res, cs.coeffs), nil
+		return bls12381r1cs.NewR1CS(r1cs, coeffs)
 	case ecc.BN254:
-		return bn254r1cs.NewR1CS(res, cs.coeffs), nil
+		return bn254r1cs.NewR1CS(r1cs, coeffs)
 	case ecc.BW6_761:
-		return bw6761r1cs.NewR1CS(res, cs.coeffs), nil
-	case ecc.UNKNOWN:
-		return &res, nil
+		return bw6761r1cs.NewR1CS(r1cs, coeffs)
 	default:
 		panic("not implemtented")
 	}
@@ -39,6 +39,9 @@ func (cs *ConstraintSystem) toSparseR1CS(curveID ecc.ID) (CompiledConstraintSyst
 
 	res.NbPublicVariables = len(cs.public.variables) - 1 // the ONE_WIRE is discarded as it is not used in PLONK
 	res.NbSecretVariables = len(cs.secret.variables)
+	res.PublicNames = cs.publicInputNames()
+	res.SecretNames = cs.secretInputNames()
+	res.MinModulusBits = cs.minModulusBits
 
 	res.Constraints = make([]compiled.SparseR1C, 0)
 	res.Assertions = make([]compiled.SparseR1C, 0)
@@ -57,10 +60,18 @@ func (cs *ConstraintSystem) toSparseR1CS(curveID ecc.ID) (CompiledConstraintSyst
 	varPcsToVarCs := make(map[idCS]idPCS)
 	solvedVariables := make([]bool, len(cs.internal.variables))
 
-	// convert the constraints invidually
+	// convert the constraints invidually, the hints are converted where they were created
+	// so that the wires they depend on are already recorded in the pcs
+	hintID := 0
 	for i := 0; i < len(cs.constraints); i++ {
+		for ; hintID < len(cs.hints) && cs.hints[hintID].nbConstraints <= i; hintID++ {
+			recordHint(&res, cs, cs.hints[hintID], varPcsToVarCs, solvedVariables)
+		}
 		r1cToSparseR1C(&res, cs, cs.constraints[i], varPcsToVarCs, solvedVariables)
 	}
+	for ; hintID < len(cs.hints); hintID++ {
+		recordHint(&res, cs, cs.hints[hintID], varPcsToVarCs, solvedVariables)
+	}
 	for i := 0; i < len(cs.assertions); i++ {
 		splitR1C(&res, cs, cs.assertions[i], varPcsToVarCs)
 	}
@@ -149,43 +160,107 @@ func (cs *ConstraintSystem) toSparseR1CS(curveID ecc.ID) (CompiledConstraintSyst
 		offsetIDs(&res.Assertions[i])
 	}
 
-	// offset IDs in the logs
-	for i := 0; i < len(cs.logs); i++ {
+	// offset the IDs in the hints
+	for i := 0; i < len(res.Hints); i++ {
+		res.Hints[i].WireID += res.NbPublicVariables + res.NbSecretVariables
+		for j := 0; j < len(res.Hints[i].Inputs); j++ {
+			for k := 0; k < len(res.Hints[i].Inputs[j]); k++ {
+				if err := offsetIDTerm(&res.Hints[i].Inputs[j][k]); err != nil {
+					return &res, err
+				}
+			}
+		}
+	}
+
+	// offset IDs in the logs and in the debug info of the assertions
+	offsetLogEntry := func(l logEntry, context string) compiled.LogEntry {
 		entry := compiled.LogEntry{
-			Format:    cs.logs[i].format,
-			ToResolve: make([]int, len(cs.logs[i].toResolve)),
+			Format:    l.format,
+			ToResolve: make([]int, 0, len(l.toResolve)),
 		}
-		for j := 0; j < len(cs.logs[i].toResolve); j++ {
-			_, _, cID, cVisibility := cs.logs[i].toResolve[j].Unpack()
+		for j := len(l.toResolve) - 1; j >= 0; j-- {
+			_, _, cID, cVisibility := l.toResolve[j].Unpack()
 			switch cVisibility {
 			case compiled.Public:
-				entry.ToResolve[j] += cID - 1 //+ res.NbInternalVariables + res.NbSecretVariables // -1 because the ONE_WIRE's is not counted
+				if cID == 0 {
+					// the ONE_WIRE is not a wire in PLONK, its value is written in the format
+					entry.Format = resolveVerb(entry.Format, j, "1")
+					continue
+				}
+				entry.ToResolve = append(entry.ToResolve, cID-1) // -1 because the ONE_WIRE's is not counted
 			case compiled.Secret:
-				entry.ToResolve[j] += cID + res.NbPublicVariables
+				entry.ToResolve = append(entry.ToResolve, cID+res.NbPublicVariables)
 			case compiled.Internal:
-				entry.ToResolve[j] = varPcsToVarCs[cID] + res.NbSecretVariables + res.NbPublicVariables
+				entry.ToResolve = append(entry.ToResolve, varPcsToVarCs[cID]+res.NbSecretVariables+res.NbPublicVariables)
 			case compiled.Unset:
-				panic("encountered unset visibility on a variable in logs id offset routine")
+				panic("encountered unset visibility on a variable in " + context + " id offset routine")
 			}
 		}
-		res.Logs[i] = entry
+		// the terms were processed in reverse order, so that resolving a verb doesn't shift the next ones
+		for i, j := 0, len(entry.ToResolve)-1; i < j; i, j = i+1, j-1 {
+			entry.ToResolve[i], entry.ToResolve[j] = entry.ToResolve[j], entry.ToResolve[i]
+		}
+		return entry
+	}
+	for i := 0; i < len(cs.logs); i++ {
+		res.Logs[i] = offsetLogEntry(cs.logs[i], "logs")
+	}
+
+	// the range checks of the binary decompositions come first, with their own debug info
+	// (see r1cToPlonkConstraintBinary), then each assertion of the cs is converted in exactly
+	// one assertion (see splitR1C)
+	for i := 0; i < len(cs.debugInfo); i++ {
+		res.DebugInfo = append(res.DebugInfo, offsetLogEntry(cs.debugInfo[i], "debugInfo"))
+	}
+
+	// offset IDs in the public outputs
+	res.Outputs = make([]compiled.PublicOutput, len(cs.outputs))
+	for i := 0; i < len(cs.outputs); i++ {
+		res.Outputs[i] = compiled.PublicOutput{
+			WireID:  cs.outputs[i].wire.id - 1, // -1 because the ONE_WIRE's is not counted
+			ValueID: varPcsToVarCs[cs.outputs[i].value] + res.NbSecretVariables + res.NbPublicVariables,
+		}
 	}
 
+	if curveID == ecc.UNKNOWN {
+		return &res, nil
+	}
+	return typedSparseR1CS(curveID, res), nil
+
+}
+
+// typedSparseR1CS returns the SparseR1CS of the curve, with the coefficients reduced in its scalar field
+func typedSparseR1CS(curveID ecc.ID, sparseR1CS compiled.SparseR1CS) CompiledConstraintSystem {
 	switch curveID {
 	case ecc.BLS12_377:
-		return bls12377r1cs.NewSparseR1CS(res, res.Coeffs), nil
+		return bls12377r1cs.NewSparseR1CS(sparseR1CS, sparseR1CS.Coeffs)
 	case ecc.BLS12_381:
-		return bls12381r1cs.NewSparseR1CS(res, res.Coeffs), nil
+		return bls12381r1cs.NewSparseR1CS(sparseR1CS, sparseR1CS.Coeffs)
 	case ecc.BN254:
-		return bn254r1cs.NewSparseR1CS(res, res.Coeffs), nil
+		return bn254r1cs.NewSparseR1CS(sparseR1CS, sparseR1CS.Coeffs)
 	case ecc.BW6_761:
-		return bw6761r1cs.NewSparseR1CS(res, res.Coeffs), nil
-	case ecc.UNKNOWN:
-		return &res, nil
+		return bw6761r1cs.NewSparseR1CS(sparseR1CS, sparseR1CS.Coeffs)
 	default:
 		panic("not implemtented")
 	}
+}
 
+// resolveVerb replaces the i-th %s verb of format by value (the escaped %% are skipped)
+func resolveVerb(format string, i int, value string) string {
+	n := 0
+	for j := 0; j < len(format)-1; j++ {
+		if format[j] != '%' {
+			continue
+		}
+		if format[j+1] == 's' {
+			if n == i {
+				return format[:j] + value + format[j+2:]
+			}
+			n++
+		}
+		j++ // skip the verb
+	}
+	return format
 }
 
 // coeffID tries to fetch the entry where b is if it exits, otherwise appends b to
@@ -365,6 +440,38 @@ func recordConstraint(pcs *compiled.SparseR1CS, c compiled.SparseR1C) {
 	pcs.Constraints = append(pcs.Constraints, c)
 }
 
+// recordHint records a hint in the pcs, and the variable it computes.
+// Since the ONE_WIRE doesn't exist in the pcs, the constant part of an
+// input is recorded in a new variable
+func recordHint(pcs *compiled.SparseR1CS, cs *ConstraintSystem, h hintEntry, csPcsMapping map[idCS]idPCS, solvedVariables []bool) {
+
+	res := compiled.Hint{
+		ID:     h.ID,
+		Inputs: make([]compiled.LinearExpression, len(h.Inputs)),
+	}
+
+	for i := 0; i < len(h.Inputs); i++ {
+		l, constant := popConstantTerm(h.Inputs[i], cs, pcs)
+		input := make(compiled.LinearExpression, 0, len(l)+1)
+		for j := 0; j < len(l); j++ {
+			input = append(input, getCorrespondingTerm(pcs, l[j], cs.coeffs, csPcsMapping))
+		}
+		if constant != 0 {
+			k := newInternalVariable(pcs)
+			recordConstraint(pcs, compiled.SparseR1C{L: negate(pcs, k), K: constant}) // -k+constant = 0
+			input = append(input, k)
+		}
+		res.Inputs[i] = input
+	}
+
+	w := newInternalVariable(pcs)
+	res.WireID = w.VariableID()
+	csPcsMapping[h.WireID] = w.VariableID()
+	solvedVariables[h.WireID] = true
+
+	pcs.Hints = append(pcs.Hints, res)
+}
+
 // recordAssertion records a plonk constraint (assertion) in the pcs
 func recordAssertion(pcs *compiled.SparseR1CS, c compiled.SparseR1C) {
 	pcs.Assertions = append(pcs.Assertions, c)
@@ -823,7 +930,6 @@ func r1cToPlonkConstraintSingleOutput(pcs *compiled.SparseR1CS, cs *ConstraintSy
 					constk.Set(&pcs.Coeffs[constantl])
 					constk.Mul(&constk, &pcs.Coeffs[constantr])
 					constk.Sub(&constk, &pcs.Coeffs[constanto])
-					constk.Neg(&constk)
 					kID := coeffID(pcs, &constk)
 
 					c.Set(&cs.coeffs[toSolve.CoeffID()])
@@ -833,7 +939,7 @@ func r1cToPlonkConstraintSingleOutput(pcs *compiled.SparseR1CS, cs *ConstraintSy
 					res.SetCoeffID(id)
 					csPcsMapping[idCS] = res.VariableID()
 
-					recordConstraint(pcs, compiled.SparseR1C{L: ot, K: kID, O: res})
+					recordConstraint(pcs, compiled.SparseR1C{L: negate(pcs, ot), K: kID, O: res})
 
 				} else { // constantl*(r + constantr) = toSolve + o + constanto
 					rt := split(pcs, 0, cs.coeffs, r, csPcsMapping)
@@ -844,7 +950,6 @@ func r1cToPlonkConstraintSingleOutput(pcs *compiled.SparseR1CS, cs *ConstraintSy
 					constk.Set(&pcs.Coeffs[constantl])
 					constk.Mul(&constk, &pcs.Coeffs[constantr])
 					constk.Sub(&constk, &pcs.Coeffs[constanto])
-					constk.Neg(&constk)
 					kID := coeffID(pcs, &constk)
 
 					c.Set(&cs.coeffs[toSolve.CoeffID()])
@@ -868,7 +973,6 @@ func r1cToPlonkConstraintSingleOutput(pcs *compiled.SparseR1CS, cs *ConstraintSy
 					constk.Set(&pcs.Coeffs[constantl])
 					constk.Mul(&constk, &pcs.Coeffs[constantr])
 					constk.Sub(&constk, &pcs.Coeffs[constanto])
-					constk.Neg(&constk)
 					kID := coeffID(pcs, &constk)
 
 					c.Set(&cs.coeffs[toSolve.CoeffID()])
@@ -891,7 +995,6 @@ func r1cToPlonkConstraintSingleOutput(pcs *compiled.SparseR1CS, cs *ConstraintSy
 					constk.Set(&pcs.Coeffs[constantl])
 					constk.Mul(&constk, &pcs.Coeffs[constantr])
 					constk.Sub(&constk, &pcs.Coeffs[constanto])
-					constk.Neg(&constk)
 					kID := coeffID(pcs, &constk)
 
 					u := newInternalVariable(pcs)
@@ -981,6 +1084,10 @@ func r1cToPlonkConstraintBinary(pcs *compiled.SparseR1CS, cs *ConstraintSystem,
 			Solver: compiled.BinaryDec,
 		})
 	}
+
+	// the last quotient is 0, that is o fits on nbBits bits
+	recordAssertion(pcs, compiled.SparseR1C{L: accQi[nbBits]})
+	pcs.DebugInfo = append(pcs.DebugInfo, compiled.LogEntry{Format: fmt.Sprintf("[toBinary] the decomposed value doesn't fit on %d bits", nbBits)})
 }
 
 // splitR1C splits a r1c assertion (meaning that
@@ -1131,7 +1238,7 @@ func splitR1C(pcs *compiled.SparseR1CS, cs *ConstraintSystem, r1c compiled.R1C,
 				constk.Sub(&constk, &pcs.Coeffs[constanto])
 				kID := coeffID(pcs, &constk)
 
-				recordConstraint(pcs, compiled.SparseR1C{
+				recordAssertion(pcs, compiled.SparseR1C{
 					L: constrlt,
 					R: constlrt,
 					M: [2]compiled.Term{lt, rt},
@@ -0,0 +1,150 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend"
+	bn254r1cs "github.com/consensys/gnark/internal/backend/bn254/cs"
+)
+
+// singleOutputCircuit records a constraint l * r = res + Y + 7, which solves the internal variable res,
+// and asserts res == Res
+type singleOutputCircuit struct {
+	l, r func(cs *ConstraintSystem, circuit *singleOutputCircuit) Variable
+	X, Y Variable
+	W    Variable
+	Res  Variable `gnark:",public"`
+}
+
+func (circuit *singleOutputCircuit) Define(curveID ecc.ID, cs API) error {
+	system := cs.(*ConstraintSystem)
+	res := system.newInternalVariable()
+	system.constraints = append(system.constraints, newR1C(circuit.l(system, circuit), circuit.r(system, circuit), cs.Add(res, circuit.Y, 7)))
+	cs.AssertIsEqual(res, circuit.Res)
+	return nil
+}
+
+// TestSingleOutputConstant checks the sign of the constant term of the PLONK constraints
+// which solve a variable of the output of a R1C, along with other variables (toSolve + o + constanto)
+func TestSingleOutputConstant(t *testing.T) {
+	const x, y, w = 3, 11, 2
+	constant := func(c int) func(*ConstraintSystem, *singleOutputCircuit) Variable {
+		return func(cs *ConstraintSystem, _ *singleOutputCircuit) Variable { return cs.Constant(c) }
+	}
+
+	for name, tc := range map[string]struct {
+		l, r func(*ConstraintSystem, *singleOutputCircuit) Variable
+		res  int // l * r - y - 7
+	}{
+		// constantl*constantr = toSolve + o + constanto
+		"constant*constant": {constant(3), constant(10), 3*10 - y - 7},
+		// constantl*(r + constantr) = toSolve + o + constanto
+		"constant*(r+c)": {
+			constant(3),
+			func(cs *ConstraintSystem, circuit *singleOutputCircuit) Variable { return cs.Add(circuit.X, 5) },
+			3*(x+5) - y - 7,
+		},
+		// (l + constantl)*constantr = toSolve + o + constanto
+		"(l+c)*constant": {
+			func(cs *ConstraintSystem, circuit *singleOutputCircuit) Variable { return cs.Add(circuit.X, 5) },
+			constant(3),
+			(x+5)*3 - y - 7,
+		},
+		// (l + constantl)*(r + constantr) = toSolve + o + constanto
+		"(l+c)*(r+c)": {
+			func(cs *ConstraintSystem, circuit *singleOutputCircuit) Variable { return cs.Add(circuit.X, 5) },
+			func(cs *ConstraintSystem, circuit *singleOutputCircuit) Variable { return cs.Add(circuit.W, 4) },
+			(x+5)*(w+4) - y - 7,
+		},
+	} {
+		ccs, err := Compile(ecc.BN254, backend.PLONK, &singleOutputCircuit{l: tc.l, r: tc.r})
+		if err != nil {
+			t.Fatal(err)
+		}
+		spr := ccs.(*bn254r1cs.SparseR1CS)
+
+		// [Res | X, Y, W]
+		witness := make([]fr.Element, 4)
+		witness[0].SetUint64(uint64(tc.res))
+		witness[1].SetUint64(x)
+		witness[2].SetUint64(y)
+		witness[3].SetUint64(w)
+		if err := spr.IsSolved(witness); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		witness[0].SetUint64(uint64(tc.res + 1))
+		if err := spr.IsSolved(witness); err == nil {
+			t.Fatalf("%s: wrong result is solved", name)
+		}
+	}
+}
+
+type binaryRangeCircuit struct {
+	X Variable
+}
+
+func (circuit *binaryRangeCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.ToBinary(circuit.X, 8)
+	return nil
+}
+
+// TestBinaryDecompositionRange checks that the binary decomposition of a PLONK circuit
+// rejects the values which don't fit on the number of bits
+func TestBinaryDecompositionRange(t *testing.T) {
+	ccs, err := Compile(ecc.BN254, backend.PLONK, &binaryRangeCircuit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spr := ccs.(*bn254r1cs.SparseR1CS)
+
+	witness := make([]fr.Element, 1)
+	witness[0].SetUint64(255)
+	if err := spr.IsSolved(witness); err != nil {
+		t.Fatal(err)
+	}
+
+	witness[0].SetUint64(256)
+	if err := spr.IsSolved(witness); err == nil {
+		t.Fatal("256 doesn't fit on 8 bits")
+	}
+}
+
+type rangeThenAssertCircuit struct {
+	X, Y Variable
+}
+
+func (circuit *rangeThenAssertCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.ToBinary(circuit.X, 8)
+	cs.AssertIsEqual(circuit.X, circuit.Y)
+	return nil
+}
+
+// TestBinaryDecompositionDebugInfo checks that the range checks of the binary decompositions
+// don't shift the debug info of the assertions which follow them
+func TestBinaryDecompositionDebugInfo(t *testing.T) {
+	ccs, err := Compile(ecc.BN254, backend.PLONK, &rangeThenAssertCircuit{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spr := ccs.(*bn254r1cs.SparseR1CS)
+
+	// [X, Y]
+	witness := make([]fr.Element, 2)
+	witness[0].SetUint64(3)
+	witness[1].SetUint64(4)
+	err = spr.IsSolved(witness)
+	if err == nil || !strings.Contains(err.Error(), "cs_to_r1cs_sparse_test.go") {
+		t.Fatalf("expected the call site of the assertion, got %v", err)
+	}
+
+	witness[0].SetUint64(256)
+	witness[1].SetUint64(256)
+	err = spr.IsSolved(witness)
+	if err == nil || !strings.Contains(err.Error(), "[toBinary]") {
+		t.Fatalf("expected the debug info of the range check, got %v", err)
+	}
+}
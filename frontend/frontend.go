@@ -3,17 +3,25 @@ package frontend
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/internal/backend/compiled"
 	"github.com/consensys/gnark/internal/parser"
+	"github.com/consensys/gnark/internal/utils"
 )
 
 // ErrInputNotSet triggered when trying to access a variable that was not allocated
 var ErrInputNotSet = errors.New("variable is not allocated")
 
+// ErrUnknownCurve triggered when a gadget needs the scalar field of the curve, and the circuit
+// is compiled without curve (see Compile)
+var ErrUnknownCurve = errors.New("the scalar field is unknown, the circuit must be compiled for a curve")
+
 // Compile will generate a CompiledConstraintSystem from the given circuit
 //
 // 1. it will first allocate the user inputs (see type Tag for more info)
@@ -29,14 +37,42 @@ var ErrInputNotSet = errors.New("variable is not allocated")
 // 3. finally, it converts that to a CompiledConstraintSystem.
 // 		if zkpID == backend.GROTH16	--> R1CS
 //		if zkpID == backend.PLONK 	--> SparseR1CS
-func Compile(curveID ecc.ID, zkpID backend.ID, circuit Circuit) (ccs CompiledConstraintSystem, err error) {
+//
+// If curveID is ecc.UNKNOWN, the constraint system is not tied to a curve: the operations on constants
+// are not reduced modulo a scalar field, and the gadgets which depend on it (Mod, IsZero, ExpConstant
+// of a constant) make Compile fail.
+// The result must be tied to a curve with SetCurve before it is used by a backend, which enables to
+// compile a circuit once for several curves.
+//
+// opts tune the compilation (see CompileOption)
+func Compile(curveID ecc.ID, zkpID backend.ID, circuit Circuit, opts ...CompileOption) (ccs CompiledConstraintSystem, err error) {
+
+	start := time.Now()
+
+	config, err := newCompileConfig(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// for PLONK, the number of constraints is known once the constraint system is converted,
+	// so the constraint budget is checked after the conversion only
+	csConfig := config
+	if zkpID != backend.GROTH16 {
+		csConfig.maxConstraints = 0
+	}
 
 	// build the constraint system (see Circuit.Define)
-	cs, err := buildCS(curveID, circuit)
+	cs, err := buildCS(curveID, circuit, csConfig)
 	if err != nil {
 		return nil, err
 	}
 
+	if config.strict {
+		if warnings := cs.analyze(); len(warnings) > 0 {
+			return nil, warningsError(warnings)
+		}
+	}
+
 	switch zkpID {
 	case backend.GROTH16:
 		ccs, err = cs.toR1CS(curveID)
@@ -49,16 +85,66 @@ func Compile(curveID ecc.ID, zkpID backend.ID, circuit Circuit) (ccs CompiledCon
 		return nil, err
 	}
 
+	// the conversion to a SparseR1CS may add constraints
+	if config.maxConstraints > 0 && ccs.GetNbConstraints() > config.maxConstraints {
+		return nil, fmt.Errorf("%w: %d > %d", ErrMaxConstraints, ccs.GetNbConstraints(), config.maxConstraints)
+	}
+
+	if config.statsHook != nil {
+		internal, secret, public := ccs.GetNbVariables()
+		config.statsHook(CompileStats{
+			NbConstraints:       ccs.GetNbConstraints(),
+			NbInternalVariables: internal,
+			NbSecretVariables:   secret,
+			NbPublicVariables:   public,
+			NbCoefficients:      ccs.GetNbCoefficients(),
+			Duration:            time.Since(start),
+		})
+	}
+
 	return
 }
 
+// SetCurve ties a constraint system compiled for ecc.UNKNOWN (see Compile) to a curve: its coefficients
+// are reduced in the scalar field of the curve, and the result can be used by the backends.
+func SetCurve(ccs CompiledConstraintSystem, curveID ecc.ID) (CompiledConstraintSystem, error) {
+	q := utils.FrModulus(curveID)
+	if q == nil {
+		return nil, fmt.Errorf("curve %s is not supported", curveID)
+	}
+	switch _ccs := ccs.(type) {
+	case *compiled.R1CS:
+		if q.BitLen() < _ccs.MinModulusBits {
+			return nil, fmt.Errorf("the scalar field of curve %s is too small, %d bits are required", curveID, _ccs.MinModulusBits)
+		}
+		r1cs := *_ccs
+		r1cs.Coeffs = nil
+		return typedR1CS(curveID, r1cs, _ccs.Coeffs), nil
+	case *compiled.SparseR1CS:
+		if q.BitLen() < _ccs.MinModulusBits {
+			return nil, fmt.Errorf("the scalar field of curve %s is too small, %d bits are required", curveID, _ccs.MinModulusBits)
+		}
+		return typedSparseR1CS(curveID, *_ccs), nil
+	default:
+		return nil, fmt.Errorf("%T is already tied to curve %s", ccs, ccs.CurveID())
+	}
+}
+
 // buildCS builds the constraint system. It bootstraps the inputs
 // allocations by parsing the circuit's underlying structure, then
 // it builds the constraint system using the Define method.
-func buildCS(curveID ecc.ID, circuit Circuit) (ConstraintSystem, error) {
+func buildCS(curveID ecc.ID, circuit Circuit, config compileConfig) (ConstraintSystem, error) {
 
 	// instantiate our constraint system
 	cs := newConstraintSystem()
+	cs.curveID = curveID
+	cs.noDebugInfo = config.noDebugInfo
+	cs.maxConstraints = config.maxConstraints
+	cs.recordCallSites = config.strict
+	if config.profile != nil {
+		config.profile.root = profileNode{}
+		cs.profile = config.profile
+	}
 
 	// leaf handlers are called when encoutering leafs in the circuit data struct
 	// leafs are Constraints that need to be initialized in the context of compiling a circuit
@@ -75,8 +161,15 @@ func buildCS(curveID ecc.ID, circuit Circuit) (ConstraintSystem, error) {
 			switch visibility {
 			case compiled.Unset, compiled.Secret:
 				tInput.Set(reflect.ValueOf(cs.newSecretVariable()))
+				cs.secret.names = append(cs.secret.names, name)
 			case compiled.Public:
 				tInput.Set(reflect.ValueOf(cs.newPublicVariable()))
+				cs.public.names = append(cs.public.names, name)
+			case compiled.Output:
+				v := cs.newPublicVariable()
+				tInput.Set(reflect.ValueOf(v))
+				cs.public.names = append(cs.public.names, name)
+				cs.outputs = append(cs.outputs, output{name: name, field: tInput, wire: v})
 			}
 
 			return nil
@@ -94,6 +187,56 @@ func buildCS(curveID ecc.ID, circuit Circuit) (ConstraintSystem, error) {
 		return cs, err
 	}
 
+	// operations on constants are evaluated while building the constraint system
+	if cs.err != nil {
+		return cs, cs.err
+	}
+
+	// the public outputs are assigned in Define
+	if err := cs.bindOutputs(); err != nil {
+		return cs, err
+	}
+	if cs.err != nil {
+		return cs, cs.err
+	}
+
+	if config.errorOnUnconstrainedInputs {
+		if names := cs.unconstrainedInputs(); len(names) > 0 {
+			return cs, fmt.Errorf("%w: %s", ErrUnconstrainedInput, strings.Join(names, ", "))
+		}
+	}
+
 	return cs, nil
 
 }
+
+// bindOutputs constrains the public outputs to be equal to the values assigned to
+// their fields in circuit.Define, and restores the fields.
+//
+// The value of an output is held by an internal variable, which is copied into the public wire
+// by the solver, before checking the assertions.
+func (cs *ConstraintSystem) bindOutputs() error {
+	one := cs.getOneVariable()
+	for i := 0; i < len(cs.outputs); i++ {
+		o := &cs.outputs[i]
+		v := o.field.Interface().(Variable)
+		o.field.Set(reflect.ValueOf(o.wire))
+
+		if len(v.linExp) == 0 || (v.visibility == compiled.Public && v.id == o.wire.id) {
+			return fmt.Errorf("output %s is not assigned in Define", o.name)
+		}
+
+		// the value must be held by an internal variable
+		if _, coeffID, variableID, visibility := v.linExp[0].Unpack(); len(v.linExp) == 1 && visibility == compiled.Internal && cs.coeffs[coeffID].Cmp(bOne) == 0 {
+			o.value = variableID
+		} else {
+			iv := cs.newInternalVariable()
+			cs.addConstraint(newR1C(v, one, iv))
+			o.value = iv.id
+		}
+
+		debugInfo := logEntry{format: "[output] " + o.name + " is not equal to its value"}
+		cs.addAssertion(newR1C(cs.internal.variables[o.value], one, o.wire), debugInfo)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontend
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrMaxConstraints triggered when the circuit has more constraints than allowed (see WithMaxConstraints)
+var ErrMaxConstraints = errors.New("maximum number of constraints exceeded")
+
+// ErrUnconstrainedInput triggered when an input is not used in any constraint (see ErrorOnUnconstrainedInputs)
+var ErrUnconstrainedInput = errors.New("input is not constrained")
+
+// CompileOption enables to set optional arguments to Compile
+type CompileOption func(config *compileConfig) error
+
+// CompileStats holds statistics about a compiled circuit (see WithStatsHook)
+type CompileStats struct {
+	NbConstraints       int           // number of constraints of the compiled constraint system
+	NbInternalVariables int           // number of internal variables of the compiled constraint system
+	NbSecretVariables   int           // number of secret inputs
+	NbPublicVariables   int           // number of public inputs
+	NbCoefficients      int           // number of distinct coefficients
+	Duration            time.Duration // time spent in Compile
+}
+
+type compileConfig struct {
+	noDebugInfo                bool
+	maxConstraints             int
+	errorOnUnconstrainedInputs bool
+	strict                     bool
+	profile                    *Profile
+	statsHook                  func(CompileStats)
+}
+
+func newCompileConfig(opts ...CompileOption) (compileConfig, error) {
+	var config compileConfig
+	for _, opt := range opts {
+		if err := opt(&config); err != nil {
+			return config, err
+		}
+	}
+	return config, nil
+}
+
+// WithoutDebugInfo disables the capture of the call stacks when building the constraint system.
+//
+// Compile is faster, but the errors reported by the solver when an assertion doesn't hold,
+// or by Compile when an input is not set, don't locate the faulty line in the circuit.
+func WithoutDebugInfo() CompileOption {
+	return func(config *compileConfig) error {
+		config.noDebugInfo = true
+		return nil
+	}
+}
+
+// WithMaxConstraints makes Compile fail (with ErrMaxConstraints) if the circuit has more than
+// maxConstraints constraints.
+//
+// The budget is checked as the constraints are recorded: circuit.Define runs to completion, and
+// Compile reports the call site of the first constraint exceeding the budget. For PLONK, the budget
+// is checked again once the circuit is converted to a SparseR1CS.
+func WithMaxConstraints(maxConstraints int) CompileOption {
+	return func(config *compileConfig) error {
+		if maxConstraints <= 0 {
+			return errors.New("maximum number of constraints must be positive")
+		}
+		config.maxConstraints = maxConstraints
+		return nil
+	}
+}
+
+// ErrorOnUnconstrainedInputs makes Compile fail (with ErrUnconstrainedInput) if a public or
+// secret input is not used in any constraint. By default, unconstrained inputs are ignored.
+func ErrorOnUnconstrainedInputs() CompileOption {
+	return func(config *compileConfig) error {
+		config.errorOnUnconstrainedInputs = true
+		return nil
+	}
+}
+
+// Strict makes Compile fail (with ErrCircuitWarnings) if the analysis of the circuit reports
+// warnings (see Analyze).
+//
+// The call sites of the internal variables and of the assertions are recorded in order to locate
+// the warnings, which makes Compile slower.
+func Strict() CompileOption {
+	return func(config *compileConfig) error {
+		config.strict = true
+		return nil
+	}
+}
+
+// WithProfile attributes each constraint recorded while calling circuit.Define to
+// its call stack, and aggregates the counts in profile (see Profile)
+func WithProfile(profile *Profile) CompileOption {
+	return func(config *compileConfig) error {
+		if profile == nil {
+			return errors.New("profile must not be nil")
+		}
+		config.profile = profile
+		return nil
+	}
+}
+
+// WithStatsHook sets a function called with the statistics of the compiled circuit
+func WithStatsHook(hook func(CompileStats)) CompileOption {
+	return func(config *compileConfig) error {
+		config.statsHook = hook
+		return nil
+	}
+}
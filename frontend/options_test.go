@@ -0,0 +1,183 @@
+package frontend
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend"
+)
+
+type optionsCircuit struct {
+	X, Y   Variable
+	Unused Variable `gnark:",public"`
+}
+
+func (circuit *optionsCircuit) Define(curveID ecc.ID, cs API) error {
+	x := circuit.X
+	for i := 0; i < 10; i++ {
+		x = cs.Mul(x, circuit.Y)
+	}
+	cs.AssertIsEqual(x, circuit.Y)
+	return nil
+}
+
+func TestCompileOptions(t *testing.T) {
+	for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+		var circuit optionsCircuit
+
+		// stats hook
+		var stats CompileStats
+		ccs, err := Compile(ecc.BN254, zkpID, &circuit, WithStatsHook(func(s CompileStats) { stats = s }))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.NbConstraints != ccs.GetNbConstraints() || stats.NbSecretVariables != 2 {
+			t.Fatal("wrong compile statistics")
+		}
+
+		// constraint budget
+		if _, err := Compile(ecc.BN254, zkpID, &circuit, WithMaxConstraints(stats.NbConstraints)); err != nil {
+			t.Fatal(err)
+		}
+		_, err = Compile(ecc.BN254, zkpID, &circuit, WithMaxConstraints(5))
+		if !errors.Is(err, ErrMaxConstraints) || errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+			t.Fatal("expected ErrMaxConstraints, got", err)
+		}
+
+		// unconstrained inputs
+		_, err = Compile(ecc.BN254, zkpID, &circuit, ErrorOnUnconstrainedInputs())
+		if !errors.Is(err, ErrUnconstrainedInput) || !strings.Contains(err.Error(), "Unused") {
+			t.Fatal("expected ErrUnconstrainedInput, got", err)
+		}
+
+		// debug info
+		if _, err := Compile(ecc.BN254, zkpID, &circuit, WithoutDebugInfo()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+type curveIndependentCircuit struct {
+	X, Y Variable
+	Z    Variable `gnark:",public"`
+}
+
+func (circuit *curveIndependentCircuit) Define(curveID ecc.ID, cs API) error {
+	// X * Y - X - 5 == Z
+	cs.AssertIsEqual(cs.Sub(cs.Mul(circuit.X, circuit.Y), cs.Add(circuit.X, 5)), circuit.Z)
+	// (-X) / (-1) == X
+	cs.AssertIsEqual(cs.Div(cs.Sub(0, circuit.X), cs.Constant(-1)), circuit.X)
+	return nil
+}
+
+type isZeroCircuit struct {
+	X Variable
+}
+
+func (circuit *isZeroCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.AssertIsEqual(cs.IsZero(circuit.X, curveID), 1)
+	return nil
+}
+
+type modCircuit struct {
+	X Variable
+}
+
+func (circuit *modCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.AssertIsEqual(cs.Mod(circuit.X, 7), 1)
+	return nil
+}
+
+type divModCircuit struct {
+	A, B Variable
+}
+
+func (circuit *divModCircuit) Define(curveID ecc.ID, cs API) error {
+	q, r := cs.DivMod(circuit.A, circuit.B, 126)
+	cs.AssertIsEqual(cs.Add(q, r), 3)
+	return nil
+}
+
+type expConstantCircuit struct {
+	X Variable
+}
+
+func (circuit *expConstantCircuit) Define(curveID ecc.ID, cs API) error {
+	cs.AssertIsEqual(cs.ExpConstant(cs.Constant(3), 5), circuit.X)
+	return nil
+}
+
+func TestCurveIndependentCompile(t *testing.T) {
+	for _, zkpID := range []backend.ID{backend.GROTH16, backend.PLONK} {
+		ccs, err := Compile(ecc.UNKNOWN, zkpID, &curveIndependentCircuit{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ccs.CurveID() != ecc.UNKNOWN {
+			t.Fatal("expected a constraint system independent of the curve")
+		}
+		reference, err := Compile(ecc.BN254, zkpID, &curveIndependentCircuit{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		typed, err := SetCurve(ccs, ecc.BN254)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if typed.CurveID() != ecc.BN254 || typed.GetNbConstraints() != reference.GetNbConstraints() {
+			t.Fatal("the constraint system tied to BN254 doesn't match the one compiled for BN254")
+		}
+
+		// [Z | X, Y], X * Y - X - 5 is negative if Y == 1
+		witness := make([]fr.Element, 3)
+		witness[0].SetBigInt(big.NewInt(-5))
+		witness[1].SetUint64(3)
+		witness[2].SetUint64(1)
+		solver := typed.(interface{ IsSolved([]fr.Element) error })
+		if err := solver.IsSolved(witness); err != nil {
+			t.Fatal(err)
+		}
+		witness[0].SetUint64(5)
+		if err := solver.IsSolved(witness); err == nil {
+			t.Fatal("wrong result is solved")
+		}
+
+		if _, err := SetCurve(typed, ecc.BLS12_381); err == nil {
+			t.Fatal("a constraint system tied to a curve can't be tied to another one")
+		}
+		if _, err := SetCurve(ccs, ecc.UNKNOWN); err == nil {
+			t.Fatal("expected an error for an unknown curve")
+		}
+
+		// the gadgets which depend on the scalar field need a curve
+		if _, err := Compile(ecc.UNKNOWN, zkpID, &isZeroCircuit{}); !errors.Is(err, ErrUnknownCurve) || errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+			t.Fatal("expected an error when calling IsZero without a curve")
+		}
+		if _, err := Compile(ecc.UNKNOWN, zkpID, &modCircuit{}); !errors.Is(err, ErrUnknownCurve) || errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+			t.Fatal("expected an error when calling Mod without a curve")
+		}
+		if _, err := Compile(ecc.UNKNOWN, zkpID, &expConstantCircuit{}); !errors.Is(err, ErrUnknownCurve) || errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+			t.Fatal("expected an error when calling ExpConstant on a constant without a curve")
+		}
+
+		// the width of the DivMod operands is checked against the scalar field when the curve is set
+		ccs, err = Compile(ecc.UNKNOWN, zkpID, &divModCircuit{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := SetCurve(ccs, ecc.BN254); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := SetCurve(ccs, ecc.BLS12_377); err == nil {
+			t.Fatal("expected an error when the DivMod operands may overflow the scalar field")
+		}
+		if _, err := Compile(ecc.BLS12_377, zkpID, &divModCircuit{}); err == nil {
+			t.Fatal("expected an error when the DivMod operands may overflow the scalar field")
+		}
+	}
+}
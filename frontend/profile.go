@@ -0,0 +1,130 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package frontend
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Profile attributes the constraints recorded by the frontend to the functions
+// calling the API, from circuit.Define to the API function (see WithProfile)
+//
+// The constraints are the R1C recorded while calling circuit.Define: for PLONK, the number
+// of constraints of the SparseR1CS differs.
+type Profile struct {
+	root profileNode
+}
+
+// profileNode aggregates the constraints recorded by a function, including its callees
+type profileNode struct {
+	function      string
+	nbConstraints int
+	children      []*profileNode
+}
+
+// NbConstraints returns the total number of constraints of the profile
+func (p *Profile) NbConstraints() int {
+	return p.root.nbConstraints
+}
+
+// Cumulative returns the number of constraints recorded by each function, including its callees.
+//
+// The functions are named as in the debug info (ex: "frontend.(*ConstraintSystem).Mul").
+func (p *Profile) Cumulative() map[string]int {
+	res := make(map[string]int)
+	var walk func(n *profileNode, onStack map[string]bool)
+	walk = func(n *profileNode, onStack map[string]bool) {
+		for _, c := range n.children {
+			// recursive calls are counted once
+			if onStack[c.function] {
+				walk(c, onStack)
+				continue
+			}
+			res[c.function] += c.nbConstraints
+			onStack[c.function] = true
+			walk(c, onStack)
+			delete(onStack, c.function)
+		}
+	}
+	walk(&p.root, make(map[string]bool))
+	return res
+}
+
+// WriteTo writes the profile as a text tree: each line is a function, with the number
+// of constraints it records (including its callees) and the share of the total.
+// The callees of a function are indented below it, sorted by decreasing number of constraints.
+func (p *Profile) WriteTo(w io.Writer) (int64, error) {
+	// the shares of an empty profile are 0
+	share := func(nbConstraints int) float64 {
+		if p.root.nbConstraints == 0 {
+			return 0
+		}
+		return 100 * float64(nbConstraints) / float64(p.root.nbConstraints)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%10d %7.2f%%  (total)\n", p.root.nbConstraints, share(p.root.nbConstraints))
+	var write func(n *profileNode, depth int)
+	write = func(n *profileNode, depth int) {
+		children := make([]*profileNode, len(n.children))
+		copy(children, n.children)
+		sort.SliceStable(children, func(i, j int) bool {
+			return children[i].nbConstraints > children[j].nbConstraints
+		})
+		for _, c := range children {
+			fmt.Fprintf(&buf, "%10d %7.2f%%  %s%s\n", c.nbConstraints, share(c.nbConstraints), strings.Repeat("  ", depth), c.function)
+			write(c, depth+1)
+		}
+	}
+	write(&p.root, 0)
+	return buf.WriteTo(w)
+}
+
+func (p *Profile) String() string {
+	var sbb strings.Builder
+	_, _ = p.WriteTo(&sbb)
+	return sbb.String()
+}
+
+// record attributes one constraint to the call stack (as returned by callStack, from the innermost call)
+func (p *Profile) record(stack []string) {
+	n := &p.root
+	n.nbConstraints++
+	for i := len(stack) - 1; i >= 0; i-- {
+		function := stack[i]
+		if j := strings.IndexByte(function, '\n'); j >= 0 {
+			function = function[:j]
+		}
+		n = n.child(function)
+		n.nbConstraints++
+	}
+}
+
+func (n *profileNode) child(function string) *profileNode {
+	for _, c := range n.children {
+		if c.function == function {
+			return c
+		}
+	}
+	c := &profileNode{function: function}
+	n.children = append(n.children, c)
+	return c
+}
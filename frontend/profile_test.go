@@ -0,0 +1,68 @@
+package frontend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+)
+
+type profileCircuit struct {
+	X, Y Variable
+}
+
+func (circuit *profileCircuit) Define(curveID ecc.ID, cs API) error {
+	z := profileGadget(cs, circuit.X)
+	cs.AssertIsEqual(cs.Mul(z, circuit.Y), 42)
+	return nil
+}
+
+func profileGadget(cs API, x Variable) Variable {
+	bits := cs.ToBinary(x, 8)
+	return cs.Xor(bits[0], bits[1])
+}
+
+func TestProfile(t *testing.T) {
+	var circuit profileCircuit
+	var p Profile
+	ccs, err := Compile(ecc.BN254, backend.GROTH16, &circuit, WithProfile(&p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.NbConstraints() != ccs.GetNbConstraints() {
+		t.Fatalf("profile has %d constraints, expected %d", p.NbConstraints(), ccs.GetNbConstraints())
+	}
+
+	cumulative := p.Cumulative()
+	if cumulative["frontend.(*profileCircuit).Define"] != p.NbConstraints() {
+		t.Fatal("all the constraints should be attributed to Define")
+	}
+	// ToBinary: 8 boolean assertions + 1 constraint, Xor: 1 constraint
+	if cumulative["frontend.profileGadget"] != 10 {
+		t.Fatal("wrong number of constraints for profileGadget", cumulative["frontend.profileGadget"])
+	}
+	if cumulative["frontend.(*ConstraintSystem).ToBinary"] != 9 {
+		t.Fatal("wrong number of constraints for ToBinary", cumulative["frontend.(*ConstraintSystem).ToBinary"])
+	}
+
+	// compiling again resets the profile
+	var circuit2 profileCircuit
+	if _, err := Compile(ecc.BN254, backend.GROTH16, &circuit2, WithProfile(&p)); err != nil {
+		t.Fatal(err)
+	}
+	if p.NbConstraints() != ccs.GetNbConstraints() {
+		t.Fatal("profile should be reset by Compile")
+	}
+
+	if !strings.Contains(p.String(), "  frontend.profileGadget\n") {
+		t.Fatal("text tree should contain profileGadget", p.String())
+	}
+}
+
+func TestEmptyProfile(t *testing.T) {
+	var p Profile
+	if s := p.String(); strings.Contains(s, "NaN") || !strings.Contains(s, "0.00%") {
+		t.Fatal("the shares of an empty profile should be 0", s)
+	}
+}
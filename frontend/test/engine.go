@@ -0,0 +1,591 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package test provides a test engine to execute circuits on concrete values.
+//
+// The engine implements frontend.API: instead of recording constraints, each function
+// computes its result (a big.Int, reduced modulo the scalar field of the curve) and each assertion
+// is checked when it is called. When an assertion doesn't hold, the engine reports the values
+// involved and the call stack, up to the Define method of the circuit.
+//
+// Since the circuit is neither compiled nor solved, this is a fast way to unit test gadgets.
+package test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/hint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/compiled"
+	"github.com/consensys/gnark/internal/parser"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+// engine implements frontend.API, and executes the circuit on big.Int values
+type engine struct {
+	curveID    ecc.ID
+	modulus    *big.Int
+	components []component // components being executed (see Call), from the outermost
+}
+
+// component is a component being executed
+type component struct {
+	path  string   // names of the enclosing components and of the component, separated by "/"
+	stack []string // call stack of the parent, from the caller of Call to the parent's Define
+}
+
+// IsSolved executes circuit.Define on the values of the witness, without compiling the circuit.
+//
+// It returns nil if all the assertions hold, and an error wrapping backend.ErrUnsatisfiedConstraint
+// describing the first assertion which doesn't hold otherwise (a failing hint is reported the same way).
+// Other panics, caused by a bug in the circuit, are not recovered. The public outputs (see gnark:",output")
+// may be left unassigned in the witness: if they are assigned, they must be equal to the values computed by Define.
+//
+// The circuit is not modified: Define is called on a copy of the circuit, which Variables are set to the values
+// of the witness. This way, the attributes of the circuit set by the user (if any) are preserved.
+func IsSolved(circuit, witness frontend.Circuit, curveID ecc.ID) (err error) {
+	e := &engine{
+		curveID: curveID,
+		modulus: utils.FrModulus(curveID),
+	}
+	if e.modulus == nil {
+		panic("not implemented")
+	}
+
+	c, err := copyWitness(circuit, witness)
+	if err != nil {
+		return err
+	}
+
+	// the engine panics when an assertion doesn't hold, since frontend.API functions don't return errors.
+	// Any other panic is a bug in the circuit or in the engine, and is not reported as an unsolved circuit.
+	defer func() {
+		if r := recover(); r != nil {
+			if _err, ok := r.(error); ok && errors.Is(_err, backend.ErrUnsatisfiedConstraint) {
+				err = _err
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	if err := c.Define(curveID, e); err != nil {
+		return err
+	}
+
+	return e.checkOutputs(c, witness)
+}
+
+// Add returns res = i1+i2+...in
+func (e *engine) Add(i1, i2 interface{}, in ...interface{}) frontend.Variable {
+	b1, b2 := e.toBigInt(i1), e.toBigInt(i2)
+	res := new(big.Int).Add(b1, b2)
+	for i := 0; i < len(in); i++ {
+		res.Add(res, e.toBigInt(in[i]))
+	}
+	return e.newVariable(res)
+}
+
+// Sub returns res = i1 - i2
+func (e *engine) Sub(i1, i2 interface{}) frontend.Variable {
+	b1, b2 := e.toBigInt(i1), e.toBigInt(i2)
+	return e.newVariable(new(big.Int).Sub(b1, b2))
+}
+
+// Mul returns res = i1 * i2 * ... in
+func (e *engine) Mul(i1, i2 interface{}, in ...interface{}) frontend.Variable {
+	b1, b2 := e.toBigInt(i1), e.toBigInt(i2)
+	res := new(big.Int).Mul(b1, b2)
+	res.Mod(res, e.modulus)
+	for i := 0; i < len(in); i++ {
+		res.Mul(res, e.toBigInt(in[i]))
+		res.Mod(res, e.modulus)
+	}
+	return e.newVariable(res)
+}
+
+// Inverse returns res = inverse(v)
+func (e *engine) Inverse(v frontend.Variable) frontend.Variable {
+	b := e.toBigInt(v)
+	if b.Sign() == 0 {
+		e.fail("[inverse] %s has no inverse", b.String())
+	}
+	return e.newVariable(new(big.Int).ModInverse(b, e.modulus))
+}
+
+// Div returns res = i1 / i2
+func (e *engine) Div(i1, i2 interface{}) frontend.Variable {
+	b1, b2 := e.toBigInt(i1), e.toBigInt(i2)
+	if b2.Sign() == 0 {
+		e.fail("[div] %s / %s, division by 0", b1.String(), b2.String())
+	}
+	res := new(big.Int).ModInverse(b2, e.modulus)
+	res.Mul(res, b1)
+	return e.newVariable(res)
+}
+
+// DivMod returns the quotient and the remainder of the integer division of a by b. a and b must fit on nbBits bits
+func (e *engine) DivMod(a, b frontend.Variable, nbBits int) (quotient, remainder frontend.Variable) {
+	b1, b2 := e.toBigInt(a), e.toBigInt(b)
+	if b1.BitLen() > nbBits || b2.BitLen() > nbBits {
+		e.fail("[divMod] %s or %s doesn't fit on %d bits", b1.String(), b2.String(), nbBits)
+	}
+	if b2.Sign() == 0 {
+		e.fail("[divMod] %s / %s, division by 0", b1.String(), b2.String())
+	}
+	var q, r big.Int
+	q.QuoRem(b1, b2, &r)
+	return e.newVariable(&q), e.newVariable(&r)
+}
+
+// Mod returns a mod m, where m is a positive constant
+func (e *engine) Mod(a frontend.Variable, m interface{}) frontend.Variable {
+	c := frontend.FromInterface(m)
+	if c.Sign() <= 0 {
+		panic("Mod: modulus " + c.String() + " is not positive")
+	}
+	var res big.Int
+	return e.newVariable(res.Mod(e.toBigInt(a), &c))
+}
+
+// Xor compute the XOR between two variables
+func (e *engine) Xor(a, b frontend.Variable) frontend.Variable {
+	b1, b2 := e.toBigInt(a), e.toBigInt(b)
+	e.mustBeBoolean("xor", b1)
+	e.mustBeBoolean("xor", b2)
+	return e.newVariable(new(big.Int).Xor(b1, b2))
+}
+
+// Or compute the OR between two variables
+func (e *engine) Or(a, b frontend.Variable) frontend.Variable {
+	b1, b2 := e.toBigInt(a), e.toBigInt(b)
+	e.mustBeBoolean("or", b1)
+	e.mustBeBoolean("or", b2)
+	return e.newVariable(new(big.Int).Or(b1, b2))
+}
+
+// And compute the AND between two variables
+func (e *engine) And(a, b frontend.Variable) frontend.Variable {
+	b1, b2 := e.toBigInt(a), e.toBigInt(b)
+	e.mustBeBoolean("and", b1)
+	e.mustBeBoolean("and", b2)
+	return e.newVariable(new(big.Int).And(b1, b2))
+}
+
+// IsZero returns 1 if a is zero, 0 otherwise
+func (e *engine) IsZero(a frontend.Variable, id ecc.ID) frontend.Variable {
+	b := e.toBigInt(a)
+	if b.Sign() == 0 {
+		return e.newVariable(big.NewInt(1))
+	}
+	return e.newVariable(big.NewInt(0))
+}
+
+// IsEqual returns 1 if i1 == i2, 0 otherwise
+func (e *engine) IsEqual(i1, i2 interface{}) frontend.Variable {
+	b1, b2 := e.toBigInt(i1), e.toBigInt(i2)
+	if b1.Cmp(b2) == 0 {
+		return e.newVariable(big.NewInt(1))
+	}
+	return e.newVariable(big.NewInt(0))
+}
+
+// IsLessOrEqual returns 1 if i1 <= i2, 0 otherwise. i1 and i2 must fit on nbBits bits
+func (e *engine) IsLessOrEqual(i1, i2 interface{}, nbBits int) frontend.Variable {
+	if e.cmp("isLessOrEqual", i1, i2, nbBits) <= 0 {
+		return e.newVariable(big.NewInt(1))
+	}
+	return e.newVariable(big.NewInt(0))
+}
+
+// IsLess returns 1 if i1 < i2, 0 otherwise. i1 and i2 must fit on nbBits bits
+func (e *engine) IsLess(i1, i2 interface{}, nbBits int) frontend.Variable {
+	if e.cmp("isLess", i1, i2, nbBits) < 0 {
+		return e.newVariable(big.NewInt(1))
+	}
+	return e.newVariable(big.NewInt(0))
+}
+
+// Cmp returns 1 if i1 > i2, 0 if i1 == i2, -1 if i1 < i2. i1 and i2 must fit on nbBits bits
+func (e *engine) Cmp(i1, i2 interface{}, nbBits int) frontend.Variable {
+	return e.newVariable(big.NewInt(int64(e.cmp("cmp", i1, i2, nbBits))))
+}
+
+// Exp returns res = base^exponent, exponent must fit on nbBits bits
+func (e *engine) Exp(base, exponent frontend.Variable, nbBits int) frontend.Variable {
+	b := e.toBigInt(exponent)
+	if b.BitLen() > nbBits {
+		e.fail("[exp] %s doesn't fit on %d bits", b.String(), nbBits)
+	}
+	var res big.Int
+	return e.newVariable(res.Exp(e.toBigInt(base), b, e.modulus))
+}
+
+// ExpConstant returns res = base^exponent, where exponent is a non-negative constant
+func (e *engine) ExpConstant(base frontend.Variable, exponent interface{}) frontend.Variable {
+	b := frontend.FromInterface(exponent)
+	if b.Sign() < 0 {
+		panic("ExpConstant: negative exponent " + b.String())
+	}
+	var res big.Int
+	return e.newVariable(res.Exp(e.toBigInt(base), &b, e.modulus))
+}
+
+// ToBinary unpacks a variable in binary, n is the number of bits of the variable
+//
+// The result in in little endian (first bit= lsb)
+func (e *engine) ToBinary(a frontend.Variable, nbBits int) []frontend.Variable {
+	b := e.toBigInt(a)
+	if b.BitLen() > nbBits {
+		e.fail("[toBinary] %s doesn't fit on %d bits", b.String(), nbBits)
+	}
+	res := make([]frontend.Variable, nbBits)
+	for i := 0; i < nbBits; i++ {
+		res[i] = e.newVariable(big.NewInt(int64(b.Bit(i))))
+	}
+	return res
+}
+
+// FromBinary packs b, seen as a fr.Element in little endian
+func (e *engine) FromBinary(b ...frontend.Variable) frontend.Variable {
+	res := new(big.Int)
+	for i := len(b) - 1; i >= 0; i-- {
+		bit := e.toBigInt(b[i])
+		e.mustBeBoolean("fromBinary", bit)
+		res.Lsh(res, 1).Add(res, bit)
+	}
+	return e.newVariable(res)
+}
+
+// Select if b is true, yields i1 else yields i2
+func (e *engine) Select(b frontend.Variable, i1, i2 interface{}) frontend.Variable {
+	bb := e.toBigInt(b)
+	e.mustBeBoolean("select", bb)
+	if bb.Sign() != 0 {
+		return e.newVariable(e.toBigInt(i1))
+	}
+	return e.newVariable(e.toBigInt(i2))
+}
+
+// Lookup2 returns i0 if b0=b1=0, i1 if b0=1 and b1=0, i2 if b0=0 and b1=1, i3 if b0=b1=1
+func (e *engine) Lookup2(b0, b1 frontend.Variable, i0, i1, i2, i3 interface{}) frontend.Variable {
+	s0, s1 := e.toBigInt(b0), e.toBigInt(b1)
+	e.mustBeBoolean("lookup2", s0)
+	e.mustBeBoolean("lookup2", s1)
+	values := []interface{}{i0, i1, i2, i3}
+	return e.newVariable(e.toBigInt(values[s0.Uint64()+2*s1.Uint64()]))
+}
+
+// Mux selects values[sel], fails if sel >= len(values)
+func (e *engine) Mux(sel frontend.Variable, values ...interface{}) frontend.Variable {
+	s := e.toBigInt(sel)
+	if !s.IsUint64() || s.Uint64() >= uint64(len(values)) {
+		e.fail("[mux] selector %s is out of range (%d values)", s.String(), len(values))
+	}
+	return e.newVariable(e.toBigInt(values[s.Uint64()]))
+}
+
+// ArrayGet returns arr[index], fails if index >= len(arr)
+func (e *engine) ArrayGet(arr []frontend.Variable, index frontend.Variable) frontend.Variable {
+	i := e.toBigInt(index)
+	if !i.IsUint64() || i.Uint64() >= uint64(len(arr)) {
+		e.fail("[arrayGet] index %s is out of range (len %d)", i.String(), len(arr))
+	}
+	return e.newVariable(e.toBigInt(arr[i.Uint64()]))
+}
+
+// NewHint calls f on the values of the inputs and returns the result
+func (e *engine) NewHint(f hint.Function, inputs ...interface{}) frontend.Variable {
+	in := make([]*big.Int, len(inputs))
+	for i := 0; i < len(inputs); i++ {
+		in[i] = e.toBigInt(inputs[i])
+	}
+	var result big.Int
+	if err := f(e.curveID, in, &result); err != nil {
+		e.fail("[hint] %v", err)
+	}
+	return e.newVariable(&result)
+}
+
+// Constant returns a Variable set to input
+func (e *engine) Constant(input interface{}) frontend.Variable {
+	return e.newVariable(e.toBigInt(input))
+}
+
+// AssertIsEqual fails if i1 != i2
+func (e *engine) AssertIsEqual(i1, i2 interface{}) {
+	b1, b2 := e.toBigInt(i1), e.toBigInt(i2)
+	if b1.Cmp(b2) != 0 {
+		e.fail("[assertIsEqual] %s == %s", b1.String(), b2.String())
+	}
+}
+
+// AssertIsBoolean fails if v != 0 && v != 1
+func (e *engine) AssertIsBoolean(v frontend.Variable) {
+	e.mustBeBoolean("assertIsBoolean", e.toBigInt(v))
+}
+
+// AssertIsLessOrEqual fails if v > bound
+func (e *engine) AssertIsLessOrEqual(v frontend.Variable, bound interface{}) {
+	b1, b2 := e.toBigInt(v), e.toBigInt(bound)
+	if b1.Cmp(b2) > 0 {
+		e.fail("[assertIsLessOrEqual] %s <= %s", b1.String(), b2.String())
+	}
+}
+
+// Println prints the values of the Variables (and the other arguments, like fmt.Println)
+// prefixed by file.go:line
+func (e *engine) Println(a ...interface{}) {
+	var sbb strings.Builder
+
+	// prefix log line with file.go:line
+	if _, file, line, ok := runtime.Caller(1); ok {
+		sbb.WriteString(filepath.Base(file))
+		sbb.WriteByte(':')
+		sbb.WriteString(strconv.Itoa(line))
+		sbb.WriteByte(' ')
+	}
+
+	for i := 0; i < len(a); i++ {
+		if i > 0 {
+			sbb.WriteByte(' ')
+		}
+		if v, ok := a[i].(frontend.Variable); ok {
+			sbb.WriteString(e.toBigInt(v).String())
+		} else {
+			sbb.WriteString(fmt.Sprint(a[i]))
+		}
+	}
+	fmt.Println(sbb.String())
+}
+
+// Call calls component.Define, name identifies the instance of the component in the errors
+func (e *engine) Call(name string, c frontend.Component) error {
+	frame := component{
+		path:  name,
+		stack: callStack(),
+	}
+	if n := len(e.components); n > 0 {
+		frame.path = e.components[n-1].path + "/" + name
+	}
+
+	e.components = append(e.components, frame)
+	defer func() {
+		e.components = e.components[:len(e.components)-1]
+	}()
+
+	if err := c.Define(e.curveID, e); err != nil {
+		return fmt.Errorf("component %s: %w", frame.path, err)
+	}
+	return nil
+}
+
+// cmp compares the values of i1 and i2, which must fit on nbBits bits
+func (e *engine) cmp(name string, i1, i2 interface{}, nbBits int) int {
+	b1, b2 := e.toBigInt(i1), e.toBigInt(i2)
+	if b1.BitLen() > nbBits || b2.BitLen() > nbBits {
+		e.fail("[%s] %s or %s doesn't fit on %d bits", name, b1.String(), b2.String(), nbBits)
+	}
+	return b1.Cmp(b2)
+}
+
+func (e *engine) mustBeBoolean(name string, b *big.Int) {
+	if !(b.IsUint64() && (b.Uint64() == 0 || b.Uint64() == 1)) {
+		e.fail("[%s] %s is not boolean", name, b.String())
+	}
+}
+
+// fail panics with an error wrapping backend.ErrUnsatisfiedConstraint, describing the
+// assertion which doesn't hold and the call stack leading to it
+func (e *engine) fail(format string, a ...interface{}) {
+	stack := callStack()
+	for i := len(e.components) - 1; i >= 0; i-- {
+		stack = append(stack, "component "+e.components[i].path)
+		stack = append(stack, e.components[i].stack...)
+	}
+	panic(fmt.Errorf("%w: %s\n%s", backend.ErrUnsatisfiedConstraint, fmt.Sprintf(format, a...), strings.Join(stack, "\n")))
+}
+
+// toBigInt returns the value of a Variable or of a constant, reduced modulo the scalar field
+func (e *engine) toBigInt(i1 interface{}) *big.Int {
+	var b big.Int
+	switch vv := i1.(type) {
+	case frontend.Variable:
+		val := frontend.GetAssignedValue(vv)
+		if val == nil {
+			panic(frontend.ErrInputNotSet)
+		}
+		b = frontend.FromInterface(val)
+	default:
+		b = frontend.FromInterface(vv)
+	}
+	return b.Mod(&b, e.modulus)
+}
+
+// newVariable returns a Variable set to b mod r
+func (e *engine) newVariable(b *big.Int) frontend.Variable {
+	var res frontend.Variable
+	var v big.Int
+	v.Mod(b, e.modulus)
+	res.Assign(v)
+	return res
+}
+
+// callStack returns the call stack of the circuit, from the caller of the engine to Define
+func callStack() []string {
+	pc := make([]uintptr, 20)
+	n := runtime.Callers(3, pc)
+	if n == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(pc[:n])
+	var res []string
+	for {
+		frame, more := frames.Next()
+		fe := strings.Split(frame.Function, "/")
+		function := fe[len(fe)-1]
+		if !strings.HasPrefix(function, "test.(*engine)") {
+			res = append(res, fmt.Sprintf("%s\n\t%s:%d", function, frame.File, frame.Line))
+		}
+		if !more || strings.HasSuffix(function, "Define") {
+			break
+		}
+	}
+	return res
+}
+
+// copyWitness returns a copy of circuit, where the Variables are set to the values of witness
+func copyWitness(circuit, witness frontend.Circuit) (frontend.Circuit, error) {
+	tVariable := reflect.TypeOf(frontend.Variable{})
+
+	// collect the values of the witness, in the order in which the Variables are defined
+	var values []interface{}
+	var collectHandler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
+		val := frontend.GetAssignedValue(tInput.Interface().(frontend.Variable))
+		if val == nil && visibility != compiled.Output {
+			return errors.New("variable " + name + " not assigned")
+		}
+		values = append(values, val)
+		return nil
+	}
+	if err := parser.Visit(witness, "", compiled.Unset, collectHandler, tVariable); err != nil {
+		return nil, err
+	}
+
+	// shallow copy of the circuit, the slices are copied so that the circuit is not modified
+	cValue := reflect.ValueOf(circuit)
+	if cValue.Kind() != reflect.Ptr {
+		return nil, errors.New("circuit must be a pointer")
+	}
+	res := reflect.New(cValue.Elem().Type())
+	res.Elem().Set(cValue.Elem())
+	copySlices(res.Elem())
+
+	// set the Variables of the copy
+	i := 0
+	var setHandler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
+		if i >= len(values) {
+			return errors.New("witness doesn't match the circuit")
+		}
+		var v frontend.Variable
+		if values[i] != nil {
+			v.Assign(values[i])
+		}
+		tInput.Set(reflect.ValueOf(v))
+		i++
+		return nil
+	}
+	if err := parser.Visit(res.Interface(), "", compiled.Unset, setHandler, tVariable); err != nil {
+		return nil, err
+	}
+	if i != len(values) {
+		return nil, errors.New("witness doesn't match the circuit")
+	}
+
+	return res.Interface().(frontend.Circuit), nil
+}
+
+// checkOutputs checks that the public outputs of c are assigned by Define, and that they
+// are equal to the values of the witness (if assigned)
+func (e *engine) checkOutputs(c, witness frontend.Circuit) error {
+	tVariable := reflect.TypeOf(frontend.Variable{})
+
+	var expected []interface{}
+	var collectHandler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
+		if visibility == compiled.Output {
+			expected = append(expected, frontend.GetAssignedValue(tInput.Interface().(frontend.Variable)))
+		}
+		return nil
+	}
+	if err := parser.Visit(witness, "", compiled.Unset, collectHandler, tVariable); err != nil {
+		return err
+	}
+
+	i := 0
+	var checkHandler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
+		if visibility != compiled.Output {
+			return nil
+		}
+		v := tInput.Interface().(frontend.Variable)
+		if frontend.GetAssignedValue(v) == nil {
+			return errors.New("output " + name + " is not assigned in Define")
+		}
+		if expected[i] != nil {
+			b1, b2 := e.toBigInt(v), e.toBigInt(expected[i])
+			if b1.Cmp(b2) != 0 {
+				return fmt.Errorf("%w: [output] %s: %s == %s", backend.ErrUnsatisfiedConstraint, name, b1.String(), b2.String())
+			}
+		}
+		i++
+		return nil
+	}
+	return parser.Visit(c, "", compiled.Unset, checkHandler, tVariable)
+}
+
+// copySlices replaces the slices reachable from v through exported fields by copies
+func copySlices(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				copySlices(v.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if v.IsNil() {
+			return
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		v.Set(c)
+		for i := 0; i < v.Len(); i++ {
+			copySlices(v.Index(i))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			copySlices(v.Index(i))
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/circuits"
+)
+
+func TestBuiltinCircuits(t *testing.T) {
+	for name, tc := range circuits.Circuits {
+		for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761} {
+			if err := IsSolved(tc.Circuit, tc.Good, curve); err != nil {
+				t.Fatalf("%s (%s): good witness: %v", name, curve.String(), err)
+			}
+			if err := IsSolved(tc.Circuit, tc.Bad, curve); err == nil {
+				t.Fatalf("%s (%s): bad witness is solved", name, curve.String())
+			}
+		}
+	}
+}
+
+type failingCircuit struct {
+	X, Y frontend.Variable
+}
+
+func (circuit *failingCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	cs.AssertIsEqual(cs.Mul(circuit.X, circuit.X), circuit.Y)
+	return nil
+}
+
+func TestUnsatisfiedConstraint(t *testing.T) {
+	var circuit, witness failingCircuit
+	witness.X.Assign(3)
+	witness.Y.Assign(10)
+
+	err := IsSolved(&circuit, &witness, ecc.BN254)
+	if !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+		t.Fatalf("expected backend.ErrUnsatisfiedConstraint, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "9 == 10") {
+		t.Fatal("error should contain the values of the assertion")
+	}
+	if !strings.Contains(err.Error(), "engine_test.go") {
+		t.Fatal("error should contain the call site of the assertion")
+	}
+
+	// the circuit must not be modified
+	if frontend.GetAssignedValue(circuit.X) != nil {
+		t.Fatal("circuit should not be assigned")
+	}
+
+	var good failingCircuit
+	good.X.Assign(3)
+	good.Y.Assign(9)
+	if err := IsSolved(&circuit, &good, ecc.BN254); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type squareComponent struct {
+	X, Y frontend.Variable
+}
+
+func (c *squareComponent) Define(curveID ecc.ID, cs frontend.API) error {
+	cs.AssertIsEqual(cs.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
+type componentCircuit struct {
+	X, Y frontend.Variable
+}
+
+func (circuit *componentCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	return cs.Call("square", &squareComponent{X: circuit.X, Y: circuit.Y})
+}
+
+func TestComponent(t *testing.T) {
+	var circuit, witness componentCircuit
+	witness.X.Assign(3)
+	witness.Y.Assign(10)
+
+	err := IsSolved(&circuit, &witness, ecc.BN254)
+	if !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+		t.Fatalf("expected backend.ErrUnsatisfiedConstraint, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "component square") {
+		t.Fatal("error should contain the component", err)
+	}
+}
+
+type outputCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",output"`
+}
+
+func (circuit *outputCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	circuit.Y = cs.Mul(circuit.X, circuit.X)
+	return nil
+}
+
+func TestOutputs(t *testing.T) {
+	var circuit outputCircuit
+
+	// the outputs may be left unassigned
+	var witness outputCircuit
+	witness.X.Assign(3)
+	if err := IsSolved(&circuit, &witness, ecc.BN254); err != nil {
+		t.Fatal(err)
+	}
+
+	var good outputCircuit
+	good.X.Assign(3)
+	good.Y.Assign(9)
+	if err := IsSolved(&circuit, &good, ecc.BN254); err != nil {
+		t.Fatal(err)
+	}
+
+	var bad outputCircuit
+	bad.X.Assign(3)
+	bad.Y.Assign(10)
+	if err := IsSolved(&circuit, &bad, ecc.BN254); !errors.Is(err, backend.ErrUnsatisfiedConstraint) {
+		t.Fatalf("expected backend.ErrUnsatisfiedConstraint, got %v", err)
+	}
+}
+
+type buggyCircuit struct {
+	X []frontend.Variable
+}
+
+func (circuit *buggyCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	cs.AssertIsEqual(circuit.X[0], 1)
+	return nil
+}
+
+func TestPanic(t *testing.T) {
+	// a bug in the circuit is not reported as an unsatisfied constraint
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("IsSolved should not recover from panics unrelated to the assertions")
+		}
+	}()
+	var circuit, witness buggyCircuit
+	_ = IsSolved(&circuit, &witness, ecc.BN254)
+}
@@ -31,8 +31,7 @@ type Wire struct {
 // circuit when there is no other choice (to avoid wasting wires doing only linear expressions)
 type Variable struct {
 	Wire
-	linExp    compiled.LinearExpression
-	isBoolean bool // TODO it doesn't work, we need a pointer for that
+	linExp compiled.LinearExpression
 }
 
 // GetAssignedValue returns the assigned value (or nil) to the variable
@@ -1,7 +1,10 @@
 package server
 
 import (
+	"bytes"
+
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/frontend"
 )
 
@@ -18,3 +21,12 @@ type circuit struct {
 	fullWitnessSize   int
 	publicWitnessSize int
 }
+
+// verify checks the proof computed from the full witness against the verifying key
+func (c *circuit) verify(proof groth16.Proof, fullWitness []byte) error {
+	var publicWitness bytes.Buffer
+	if _, err := witness.WritePublicFromFull(&publicWitness, c.r1cs, bytes.NewReader(fullWitness)); err != nil {
+		return err
+	}
+	return groth16.ReadAndVerify(proof, c.vk, &publicWitness)
+}
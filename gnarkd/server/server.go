@@ -34,7 +34,9 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 
 	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/gnarkd/pb"
+	"github.com/consensys/gnark/internal/backend/bigint"
 )
 
 const (
@@ -151,14 +153,24 @@ func (s *Server) startWorker(ctx context.Context) {
 
 			// run prove
 			proof, err := groth16.ReadAndProve(circuit.r1cs, circuit.pk, bytes.NewReader(job.witness))
-			job.witness = nil // set witness to nil
 			if err != nil {
+				job.witness = nil // set witness to nil
 				s.log.Errorw("proving job failed", "jobID", jobID.String(), "circuitID", job.circuitID, "err", err)
 				job.err = err
 				s.updateJobStatusOrDie(job, pb.ProveJobResult_ERRORED)
 				continue
 			}
 
+			// verify the proof before returning it
+			err = circuit.verify(proof, job.witness)
+			job.witness = nil // set witness to nil
+			if err != nil {
+				s.log.Errorw("proof verification failed", "jobID", jobID.String(), "circuitID", job.circuitID, "err", err)
+				job.err = err
+				s.updateJobStatusOrDie(job, pb.ProveJobResult_ERRORED)
+				continue
+			}
+
 			// serialize proof
 			buf.Reset()
 			_, err = proof.WriteTo(&buf)
@@ -351,10 +363,15 @@ func (s *Server) loadCircuit(curveID ecc.ID, baseDir string) error {
 			if circuit.r1cs != nil {
 				return fmt.Errorf("%s contains multiple %s files", baseDir, pkExt)
 			}
-			circuit.r1cs = groth16.NewCS(curveID)
-			if err := loadGnarkObject(circuit.r1cs, filepath.Join(baseDir, f.Name())); err != nil {
+			r1cs, err := loadConstraintSystem(filepath.Join(baseDir, f.Name()))
+			if err != nil {
 				return err
 			}
+			// the curve and the backend are read from the file
+			if _, ok := r1cs.(bigint.R1CS); !ok || r1cs.CurveID() != curveID {
+				return fmt.Errorf("%s is not a groth16 R1CS over %s", filepath.Join(baseDir, f.Name()), curveID.String())
+			}
+			circuit.r1cs = r1cs
 		}
 	}
 
@@ -389,3 +406,12 @@ func loadGnarkObject(o io.ReaderFrom, filePath string) error {
 	file.Close()
 	return err
 }
+
+func loadConstraintSystem(filePath string) (frontend.CompiledConstraintSystem, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return frontend.ReadConstraintSystem(file)
+}
@@ -34,6 +34,12 @@ func (s *Server) Prove(ctx context.Context, request *pb.ProveRequest) (*pb.Prove
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	// verify the proof before returning it
+	if err := circuit.verify(proof, request.Witness); err != nil {
+		s.log.Error(err)
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
 	// serialize proof
 	var buf bytes.Buffer
 	_, err = proof.WriteTo(&buf)
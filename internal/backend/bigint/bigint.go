@@ -0,0 +1,95 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bigint exposes the witnesses and the constraint systems of the curves on big.Int values,
+// so that the code which doesn't depend on the curve is written once.
+//
+// The implementations are generated in internal/backend/<curve> (see internal/generator/backend).
+package bigint
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	witness_bls12377 "github.com/consensys/gnark/internal/backend/bls12-377/witness"
+	witness_bls12381 "github.com/consensys/gnark/internal/backend/bls12-381/witness"
+	witness_bn254 "github.com/consensys/gnark/internal/backend/bn254/witness"
+	witness_bw6761 "github.com/consensys/gnark/internal/backend/bw6-761/witness"
+	"github.com/consensys/gnark/internal/backend/compiled"
+)
+
+// Encoding encodes the witnesses of a curve (see witness.Encoding)
+type Encoding interface {
+	// FromFullAssignment returns the values of the public inputs followed by the values of the secret inputs
+	FromFullAssignment(assignment frontend.Circuit) ([]big.Int, error)
+	// WriteTo encodes the values, reduced modulo the scalar field, in the binary format of a witness
+	WriteTo(w io.Writer, values []big.Int) (int64, error)
+	// LimitReadFrom decodes a binary witness of expectedSize values
+	LimitReadFrom(r io.Reader, expectedSize int) ([]big.Int, error)
+}
+
+// ConstraintSystem is implemented by the R1CS and the SparseR1CS of each curve
+type ConstraintSystem interface {
+	frontend.CompiledConstraintSystem
+
+	// SolveBigInt solves the constraint system for the witness [ public | secret ] and returns the values of all the wires,
+	// and if diagnostic is set, the constraints which don't hold instead of an error
+	SolveBigInt(witness []big.Int, diagnostic bool) ([]big.Int, []compiled.UnsatisfiedConstraint, error)
+	// SolveOutputsBigInt solves the constraint system and returns the values of the public outputs
+	SolveOutputsBigInt(witness []big.Int) ([]big.Int, error)
+}
+
+// R1CS is implemented by the R1CS of each curve
+type R1CS interface {
+	ConstraintSystem
+
+	// ToCompiled returns the curve independent R1CS and its coefficients
+	ToCompiled() (*compiled.R1CS, []big.Int)
+}
+
+// EncodingOf returns the encoding of the witnesses of the curve
+func EncodingOf(curveID ecc.ID) Encoding {
+	switch curveID {
+	case ecc.BN254:
+		return witness_bn254.Encoding{}
+	case ecc.BLS12_377:
+		return witness_bls12377.Encoding{}
+	case ecc.BLS12_381:
+		return witness_bls12381.Encoding{}
+	case ecc.BW6_761:
+		return witness_bw6761.Encoding{}
+	default:
+		panic("not implemented")
+	}
+}
+
+// Solve solves ccs for the full assignment, and returns the values of all the wires (see ConstraintSystem.SolveBigInt)
+func Solve(ccs ConstraintSystem, assignment frontend.Circuit, diagnostic bool) ([]big.Int, []compiled.UnsatisfiedConstraint, error) {
+	witness, err := EncodingOf(ccs.CurveID()).FromFullAssignment(assignment)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ccs.SolveBigInt(witness, diagnostic)
+}
+
+// SolveOutputs solves ccs for the full assignment, and returns the values of the public outputs
+func SolveOutputs(ccs ConstraintSystem, assignment frontend.Circuit) ([]big.Int, error) {
+	witness, err := EncodingOf(ccs.CurveID()).FromFullAssignment(assignment)
+	if err != nil {
+		return nil, err
+	}
+	return ccs.SolveOutputsBigInt(witness)
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package groth16
+
+import (
+	"errors"
+	"io"
+)
+
+// ExportSnarkJS not implemented for BLS12-377
+func (vk *VerifyingKey) ExportSnarkJS(w io.Writer) error {
+	return errors.New("not implemented")
+}
+
+// ImportSnarkJS not implemented for BLS12-377
+func (vk *VerifyingKey) ImportSnarkJS(r io.Reader) error {
+	return errors.New("not implemented")
+}
+
+// ExportSnarkJS not implemented for BLS12-377
+func (proof *Proof) ExportSnarkJS(w io.Writer) error {
+	return errors.New("not implemented")
+}
+
+// ImportSnarkJS not implemented for BLS12-377
+func (proof *Proof) ImportSnarkJS(r io.Reader) error {
+	return errors.New("not implemented")
+}
@@ -17,11 +17,16 @@
 package cs
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/fxamacker/cbor/v2"
 
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/hint"
 	"github.com/consensys/gnark/internal/backend/compiled"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
@@ -44,6 +49,8 @@ func NewSparseR1CS(r1cs compiled.SparseR1CS, coefficients []big.Int) *SparseR1CS
 	for i := 0; i < len(coefficients); i++ {
 		cs.Coefficients[i].SetBigInt(&coefficients[i])
 	}
+	// the coefficients are held by cs.Coefficients
+	cs.Coeffs, cs.CoeffsIDs = nil, nil
 	return &cs
 }
 
@@ -62,6 +69,55 @@ func (cs *SparseR1CS) CurveID() ecc.ID {
 	return ecc.BLS12_381
 }
 
+// WriteTo encodes SparseR1CS into provided io.Writer using cbor, framed by a compiled.Header
+// (see compiled.WriteFrame)
+func (cs *SparseR1CS) WriteTo(w io.Writer) (int64, error) {
+	var payload bytes.Buffer
+	encoder := cbor.NewEncoder(&payload)
+
+	// encode our object
+	if err := encoder.Encode(cs); err != nil {
+		return 0, err
+	}
+
+	header := compiled.Header{
+		CurveID:             cs.CurveID(),
+		BackendID:           backend.PLONK,
+		NbConstraints:       uint64(len(cs.Constraints)),
+		NbInternalVariables: uint64(cs.NbInternalVariables),
+		NbSecretVariables:   uint64(cs.NbSecretVariables),
+		NbPublicVariables:   uint64(cs.NbPublicVariables),
+	}
+	return compiled.WriteFrame(w, header, payload.Bytes())
+}
+
+// WriteRawTo encodes SparseR1CS into provided io.Writer, the encoding is the same as WriteTo
+// (a SparseR1CS holds no curve point)
+func (cs *SparseR1CS) WriteRawTo(w io.Writer) (int64, error) {
+	return cs.WriteTo(w)
+}
+
+// ReadFrom attempts to decode SparseR1CS from io.Reader (see WriteTo)
+//
+// The header must describe a SparseR1CS over the curve of cs.
+func (cs *SparseR1CS) ReadFrom(r io.Reader) (int64, error) {
+	header, payload, n, err := compiled.ReadFrame(r)
+	if err != nil {
+		return n, err
+	}
+	if err := header.Check(cs.CurveID(), backend.PLONK); err != nil {
+		return n, err
+	}
+
+	if err := cbor.Unmarshal(payload, cs); err != nil {
+		return n, err
+	}
+	if err := header.CheckCounts(len(cs.Constraints), cs.NbInternalVariables, cs.NbSecretVariables, cs.NbPublicVariables); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
 // find unsolved variable
 // returns 0 if the variable to solve is L, 1 if it's R, 2 if it's O
 func findUnsolvedVariable(c compiled.SparseR1C, wireInstantiated []bool) int {
@@ -164,6 +220,61 @@ func (cs *SparseR1CS) solveConstraint(c compiled.SparseR1C, wireInstantiated []b
 
 }
 
+// solveHintWires solves the wires of c which are computed by a hint and not instantiated yet
+func (cs *SparseR1CS) solveHintWires(c compiled.SparseR1C, mHints map[int]*compiled.Hint, wireInstantiated []bool, solution []fr.Element) error {
+	for _, t := range [5]compiled.Term{c.L, c.R, c.O, c.M[0], c.M[1]} {
+		if t.CoeffID() == 0 {
+			continue // the term is not set
+		}
+		vID := t.VariableID()
+		if h, ok := mHints[vID]; ok && !wireInstantiated[vID] {
+			if err := cs.solveHint(h, mHints, wireInstantiated, solution); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// solveHint computes the wire h.WireID by calling the hint function on the values of its inputs
+// if an input is computed by another hint, it is solved first
+func (cs *SparseR1CS) solveHint(h *compiled.Hint, mHints map[int]*compiled.Hint, wireInstantiated []bool, solution []fr.Element) error {
+	f, ok := hint.Lookup(h.ID)
+	if !ok {
+		return fmt.Errorf("%w: id %d", hint.ErrNotFound, h.ID)
+	}
+
+	inputs := make([]*big.Int, len(h.Inputs))
+	for i := 0; i < len(h.Inputs); i++ {
+		var v fr.Element
+		for _, t := range h.Inputs[i] {
+			vID := t.VariableID()
+			if !wireInstantiated[vID] {
+				_h, ok := mHints[vID]
+				if !ok {
+					return fmt.Errorf("hint input (wire %d) is not instantiated", vID)
+				}
+				if err := cs.solveHint(_h, mHints, wireInstantiated, solution); err != nil {
+					return err
+				}
+			}
+			tv := cs.computeTerm(t, solution)
+			v.Add(&v, &tv)
+		}
+		inputs[i] = new(big.Int)
+		v.ToBigIntRegular(inputs[i])
+	}
+
+	var result big.Int
+	if err := f(cs.CurveID(), inputs, &result); err != nil {
+		return err
+	}
+	solution[h.WireID].SetBigInt(&result)
+	wireInstantiated[h.WireID] = true
+
+	return nil
+}
+
 // IsSolved returns nil if given witness solves the R1CS and error otherwise
 // this method wraps r1cs.Solve() and allocates r1cs.Solve() inputs
 func (cs *SparseR1CS) IsSolved(witness []fr.Element) error {
@@ -171,24 +282,23 @@ func (cs *SparseR1CS) IsSolved(witness []fr.Element) error {
 	return err
 }
 
-// checkConstraint verifies that the constraint holds
-func (cs *SparseR1CS) checkConstraint(c compiled.SparseR1C, solution []fr.Element) error {
-	var res, a, b, zero fr.Element
-	res = cs.computeTerm(c.L, solution)
-	a = cs.computeTerm(c.R, solution)
-	res.Add(&res, &a)
-	a = cs.computeTerm(c.M[0], solution)
-	b = cs.computeTerm(c.M[1], solution)
-	a.Mul(&a, &b)
-	res.Add(&res, &a)
-	a = cs.computeTerm(c.O, solution)
-	res.Add(&res, &a)
-	a = cs.Coefficients[c.K]
-	res.Add(&res, &a)
-	if !res.Equal(&zero) {
-		return fmt.Errorf("%w", ErrUnsatisfiedConstraint)
-	}
-	return nil
+// evaluateConstraint returns the values of the terms of the constraint: qL.xa, qR.xb, qM.xa.xb, qO.xc and qK
+func (cs *SparseR1CS) evaluateConstraint(c compiled.SparseR1C, solution []fr.Element) (l, r, m, o, k fr.Element) {
+	l = cs.computeTerm(c.L, solution)
+	r = cs.computeTerm(c.R, solution)
+	m = cs.computeTerm(c.M[0], solution)
+	m1 := cs.computeTerm(c.M[1], solution)
+	m.Mul(&m, &m1)
+	o = cs.computeTerm(c.O, solution)
+	k = cs.Coefficients[c.K]
+	return
+}
+
+// checkConstraint returns true if the constraint holds
+func (cs *SparseR1CS) checkConstraint(c compiled.SparseR1C, solution []fr.Element) bool {
+	res, r, m, o, k := cs.evaluateConstraint(c, solution)
+	res.Add(&res, &r).Add(&res, &m).Add(&res, &o).Add(&res, &k)
+	return res.IsZero()
 }
 
 // Solve sets all the wires.
@@ -196,6 +306,12 @@ func (cs *SparseR1CS) checkConstraint(c compiled.SparseR1C, solution []fr.Elemen
 // witness: contains the input variables
 // it returns the full slice of wires
 func (cs *SparseR1CS) Solve(witness []fr.Element) (solution []fr.Element, err error) {
+	return cs.solve(witness, nil)
+}
+
+// solve implements Solve: if unsatisfied is not nil, the solver doesn't stop at the first
+// constraint which doesn't hold, but records it in unsatisfied (see SolveDiagnostic)
+func (cs *SparseR1CS) solve(witness []fr.Element, unsatisfied *[]compiled.UnsatisfiedConstraint) (solution []fr.Element, err error) {
 
 	expectedWitnessSize := int(cs.NbPublicVariables + cs.NbSecretVariables)
 	if len(witness) != expectedWitnessSize {
@@ -219,24 +335,56 @@ func (cs *SparseR1CS) Solve(witness []fr.Element) (solution []fr.Element, err er
 		wireInstantiated[i] = true
 	}
 
+	// the public outputs are computed by the solver
+	for i := 0; i < len(cs.Outputs); i++ {
+		wireInstantiated[cs.Outputs[i].WireID] = false
+	}
+
 	// defer log printing once all wireValues are computed
 	defer cs.printLogs(solution, wireInstantiated)
 
+	// wires computed by hints are solved when they are first needed
+	mHints := make(map[int]*compiled.Hint, len(cs.Hints))
+	for i := 0; i < len(cs.Hints); i++ {
+		mHints[cs.Hints[i].WireID] = &cs.Hints[i]
+	}
+
 	// loop through the constraints to solve the variables
 	for i := 0; i < len(cs.Constraints); i++ {
-		cs.solveConstraint(cs.Constraints[i], wireInstantiated, solution)
-		err = cs.checkConstraint(cs.Constraints[i], solution)
-		if err != nil {
-			fmt.Printf("%d-th constraint\n", i)
+		if err = cs.solveHintWires(cs.Constraints[i], mHints, wireInstantiated, solution); err != nil {
 			return solution, err
 		}
+		cs.solveConstraint(cs.Constraints[i], wireInstantiated, solution)
+		if !cs.checkConstraint(cs.Constraints[i], solution) {
+			if unsatisfied == nil {
+				return solution, fmt.Errorf("%w: constraint %d: %s", backend.ErrUnsatisfiedConstraint, i, errComputationalConstraint)
+			}
+			*unsatisfied = append(*unsatisfied, cs.unsatisfiedSparseR1C(i, false, solution, wireInstantiated))
+		}
+	}
+
+	// solve the remaining hints (the ones which appear only in assertions)
+	for i := 0; i < len(cs.Hints); i++ {
+		if !wireInstantiated[cs.Hints[i].WireID] {
+			if err = cs.solveHint(&cs.Hints[i], mHints, wireInstantiated, solution); err != nil {
+				return solution, err
+			}
+		}
+	}
+
+	// set the public outputs, before checking the assertions binding them to their values
+	for i := 0; i < len(cs.Outputs); i++ {
+		solution[cs.Outputs[i].WireID] = solution[cs.Outputs[i].ValueID]
+		wireInstantiated[cs.Outputs[i].WireID] = true
 	}
 
 	// loop through the assertions and check consistency
 	for i := 0; i < len(cs.Assertions); i++ {
-		err = cs.checkConstraint(cs.Assertions[i], solution)
-		if err != nil {
-			return solution, err
+		if !cs.checkConstraint(cs.Assertions[i], solution) {
+			if unsatisfied == nil {
+				return solution, fmt.Errorf("%w: %s", backend.ErrUnsatisfiedConstraint, cs.assertionDebugInfo(i, solution, wireInstantiated))
+			}
+			*unsatisfied = append(*unsatisfied, cs.unsatisfiedSparseR1C(i, true, solution, wireInstantiated))
 		}
 	}
 
@@ -244,6 +392,102 @@ func (cs *SparseR1CS) Solve(witness []fr.Element) (solution []fr.Element, err er
 
 }
 
+// SolveDiagnostic solves the SparseR1CS like Solve, but doesn't stop at the first constraint which doesn't hold:
+// it returns the values of all the wires and the constraints which don't hold (if any)
+// witness = [publicInputs | secretInputs]
+func (cs *SparseR1CS) SolveDiagnostic(witness []fr.Element) ([]fr.Element, []compiled.UnsatisfiedConstraint, error) {
+	var unsatisfied []compiled.UnsatisfiedConstraint
+	solution, err := cs.solve(witness, &unsatisfied)
+	if err != nil {
+		return nil, nil, err
+	}
+	return solution, unsatisfied, nil
+}
+
+// assertionDebugInfo returns the debug info of the i-th assertion, with the values of the wires
+func (cs *SparseR1CS) assertionDebugInfo(i int, solution []fr.Element, wireInstantiated []bool) string {
+	if i >= len(cs.DebugInfo) {
+		return fmt.Sprintf("assertion %d", i)
+	}
+	return logValue(cs.DebugInfo[i], solution, wireInstantiated)
+}
+
+// unsatisfiedSparseR1C describes the i-th constraint (or assertion), which doesn't hold
+func (cs *SparseR1CS) unsatisfiedSparseR1C(i int, assertion bool, solution []fr.Element, wireInstantiated []bool) compiled.UnsatisfiedConstraint {
+	res := compiled.UnsatisfiedConstraint{
+		Assertion: assertion,
+		ID:        i,
+	}
+	var c compiled.SparseR1C
+	if assertion {
+		c = cs.Assertions[i]
+		res.DebugInfo = cs.assertionDebugInfo(i, solution, wireInstantiated)
+	} else {
+		c = cs.Constraints[i]
+		res.DebugInfo = errComputationalConstraint
+	}
+
+	l, r, m, o, k := cs.evaluateConstraint(c, solution)
+	l.ToBigIntRegular(&res.L)
+	r.ToBigIntRegular(&res.R)
+	m.ToBigIntRegular(&res.M)
+	o.ToBigIntRegular(&res.O)
+	k.ToBigIntRegular(&res.K)
+
+	// the terms which are not set are equal to zero
+	seen := make(map[int]struct{})
+	for _, t := range [5]compiled.Term{c.L, c.R, c.M[0], c.M[1], c.O} {
+		if t == 0 {
+			continue
+		}
+		if _, ok := seen[t.VariableID()]; !ok {
+			seen[t.VariableID()] = struct{}{}
+			res.Wires = append(res.Wires, t.VariableID())
+		}
+	}
+	return res
+}
+
+// SolveOutputs solves the SparseR1CS and returns the values of the public outputs (see gnark:",output")
+// witness = [publicInputs | secretInputs], the values of the public outputs are ignored
+func (cs *SparseR1CS) SolveOutputs(witness []fr.Element) ([]fr.Element, error) {
+	solution, err := cs.Solve(witness)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]fr.Element, len(cs.Outputs))
+	for i := 0; i < len(cs.Outputs); i++ {
+		res[i] = solution[cs.Outputs[i].WireID]
+	}
+	return res, nil
+}
+
+// SolveBigInt solves the SparseR1CS like Solve, or like SolveDiagnostic if diagnostic is set,
+// on big.Int values (see internal/backend/bigint)
+func (cs *SparseR1CS) SolveBigInt(witness []big.Int, diagnostic bool) ([]big.Int, []compiled.UnsatisfiedConstraint, error) {
+	var solution []fr.Element
+	var unsatisfied []compiled.UnsatisfiedConstraint
+	var err error
+	if diagnostic {
+		solution, unsatisfied, err = cs.SolveDiagnostic(toElements(witness))
+	} else {
+		solution, err = cs.Solve(toElements(witness))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return toBigInts(solution), unsatisfied, nil
+}
+
+// SolveOutputsBigInt solves the SparseR1CS like SolveOutputs, on big.Int values (see internal/backend/bigint)
+func (cs *SparseR1CS) SolveOutputsBigInt(witness []big.Int) ([]big.Int, error) {
+	outputs, err := cs.SolveOutputs(toElements(witness))
+	if err != nil {
+		return nil, err
+	}
+	return toBigInts(outputs), nil
+}
+
 func logValue(entry compiled.LogEntry, wireValues []fr.Element, wireInstantiated []bool) string {
 	var toResolve []interface{}
 	for j := 0; j < len(entry.ToResolve); j++ {
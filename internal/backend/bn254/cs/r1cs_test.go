@@ -18,11 +18,14 @@ package cs_test
 
 import (
 	"bytes"
+	"errors"
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/internal/backend/circuits"
+	"github.com/consensys/gnark/internal/backend/compiled"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/consensys/gnark/internal/backend/bn254/cs"
@@ -66,3 +69,94 @@ func TestSerialization(t *testing.T) {
 		}
 	}
 }
+
+func TestSparseSerialization(t *testing.T) {
+	var buffer bytes.Buffer
+	for name, circuit := range circuits.Circuits {
+		buffer.Reset()
+
+		spr, err := frontend.Compile(ecc.BN254, backend.PLONK, circuit.Circuit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testing.Short() && spr.GetNbConstraints() > 50 {
+			continue
+		}
+
+		{
+			t.Log(name)
+			var err error
+			var written, read int64
+			written, err = spr.WriteTo(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var reconstructed cs.SparseR1CS
+			read, err = reconstructed.ReadFrom(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if written != read {
+				t.Fatal("didn't read same number of bytes we wrote")
+			}
+			// compare both
+			if !reflect.DeepEqual(spr, &reconstructed) {
+				t.Fatal("round trip serialization failed")
+			}
+
+			// a SparseR1CS is not a R1CS
+			buffer.Reset()
+			if _, err := spr.WriteTo(&buffer); err != nil {
+				t.Fatal(err)
+			}
+			var r1cs cs.R1CS
+			if _, err := r1cs.ReadFrom(&buffer); err == nil || !strings.Contains(err.Error(), "expected groth16") {
+				t.Fatalf("expected a backend mismatch error, got %v", err)
+			}
+		}
+	}
+}
+
+func TestSerializationErrors(t *testing.T) {
+	var buffer bytes.Buffer
+	r1cs, err := frontend.Compile(ecc.BN254, backend.GROTH16, circuits.Circuits["reference_small"].Circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r1cs.WriteTo(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+
+	// not a constraint system
+	var reconstructed cs.R1CS
+	if _, err := reconstructed.ReadFrom(bytes.NewReader(data[1:])); !errors.Is(err, compiled.ErrInvalidFormat) {
+		t.Fatalf("expected an invalid format error, got %v", err)
+	}
+
+	// corrupted payload
+	corrupted := make([]byte, len(data))
+	copy(corrupted, data)
+	corrupted[len(corrupted)-1] ^= 1
+	if _, err := reconstructed.ReadFrom(bytes.NewReader(corrupted)); !errors.Is(err, compiled.ErrInvalidFormat) {
+		t.Fatalf("expected an invalid format error, got %v", err)
+	}
+
+	// truncated payload
+	if _, err := reconstructed.ReadFrom(bytes.NewReader(data[:len(data)-1])); !errors.Is(err, compiled.ErrInvalidFormat) {
+		t.Fatalf("expected an invalid format error, got %v", err)
+	}
+
+	// other curve
+	buffer.Reset()
+	other, err := frontend.Compile(ecc.BLS12_381, backend.GROTH16, circuits.Circuits["reference_small"].Circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.WriteTo(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reconstructed.ReadFrom(&buffer); err == nil || !strings.Contains(err.Error(), "expected "+ecc.BN254.String()) {
+		t.Fatalf("expected a curve mismatch error, got %v", err)
+	}
+}
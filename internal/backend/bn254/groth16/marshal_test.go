@@ -24,6 +24,9 @@ import (
 	"bytes"
 	"math/big"
 	"reflect"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
@@ -241,6 +244,150 @@ func TestProvingKeySerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestProofSnarkJS(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("Proof -> snarkjs -> Proof should stay constant", prop.ForAll(
+		func(ar, krs curve.G1Affine, bs curve.G2Affine) bool {
+			var proof, pSnarkJS Proof
+			proof.Ar = ar
+			proof.Krs = krs
+			proof.Bs = bs
+
+			var buf bytes.Buffer
+			if err := proof.ExportSnarkJS(&buf); err != nil {
+				t.Log(err)
+				return false
+			}
+			if err := pSnarkJS.ImportSnarkJS(&buf); err != nil {
+				t.Log(err)
+				return false
+			}
+
+			return reflect.DeepEqual(&proof, &pSnarkJS)
+		},
+		GenG1(),
+		GenG1(),
+		GenG2(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestVerifyingKeySnarkJS(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("VerifyingKey -> snarkjs -> VerifyingKey should stay constant", prop.ForAll(
+		func(p1 curve.G1Affine, p2 curve.G2Affine) bool {
+			var vk, vkSnarkJS VerifyingKey
+
+			// create a random vk, [β]1 and [δ]1 are not in the snarkjs layout
+			nbWires := 6
+
+			vk.G1.Alpha = p1
+
+			vk.G2.Gamma = p2
+			vk.G2.Beta = p2
+			vk.G2.Delta = p2
+
+			var err error
+			vk.e, err = curve.Pair([]curve.G1Affine{vk.G1.Alpha}, []curve.G2Affine{vk.G2.Beta})
+			if err != nil {
+				t.Fatal(err)
+				return false
+			}
+			vk.G2.deltaNeg.Neg(&vk.G2.Delta)
+			vk.G2.gammaNeg.Neg(&vk.G2.Gamma)
+
+			// the first point is left at infinity
+			vk.G1.K = make([]curve.G1Affine, nbWires)
+			for i := 1; i < nbWires; i++ {
+				vk.G1.K[i] = p1
+			}
+
+			var buf bytes.Buffer
+			if err := vk.ExportSnarkJS(&buf); err != nil {
+				t.Log(err)
+				return false
+			}
+			if err := vkSnarkJS.ImportSnarkJS(&buf); err != nil {
+				t.Log(err)
+				return false
+			}
+
+			return reflect.DeepEqual(&vk, &vkSnarkJS)
+		},
+		GenG1(),
+		GenG2(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestSnarkJSErrors(t *testing.T) {
+	_, _, g1GenAff, g2GenAff := curve.Generators()
+	var proof Proof
+	proof.Ar.ScalarMultiplication(&g1GenAff, big.NewInt(42))
+	proof.Krs = g1GenAff
+	proof.Bs = g2GenAff
+
+	var buf bytes.Buffer
+	if err := proof.ExportSnarkJS(&buf); err != nil {
+		t.Fatal(err)
+	}
+	proofJSON := buf.String()
+	if !strings.Contains(proofJSON, `"curve": "bn128"`) {
+		t.Fatalf("unexpected snarkjs proof:\n%s", proofJSON)
+	}
+
+	x := proof.Ar.X.String()
+	for name, invalid := range map[string]string{
+		"protocol":     strings.Replace(proofJSON, `"groth16"`, `"plonk"`, 1),
+		"curve":        strings.Replace(proofJSON, `"curve": "`, `"curve": "bw6761`, 1),
+		"not on curve": strings.Replace(proofJSON, `"`+x+`"`, `"3"`, 1),
+		"coordinate":   strings.Replace(proofJSON, `"`+x+`"`, `"0x3"`, 1),
+		"not reduced":  strings.Replace(proofJSON, `"`+x+`"`, `"`+fp.Modulus().String()+`"`, 1),
+	} {
+		var p Proof
+		if err := p.ImportSnarkJS(strings.NewReader(invalid)); err == nil {
+			t.Fatalf("%s: importing an invalid proof should fail", name)
+		}
+	}
+
+	// snarkjs exports normalized projective coordinates (z = 1)
+	invalid := strings.Replace(proofJSON, `"1"
+ ],
+ "pi_b"`, `"2"
+ ],
+ "pi_b"`, 1)
+	if invalid == proofJSON {
+		t.Fatalf("unexpected snarkjs proof:\n%s", proofJSON)
+	}
+	if err := proof.ImportSnarkJS(strings.NewReader(invalid)); err == nil {
+		t.Fatal("importing projective coordinates with z != 1 should fail")
+	}
+
+	// the number of public inputs doesn't match the IC points
+	var vk VerifyingKey
+	vk.G1.K = make([]curve.G1Affine, 3)
+	buf.Reset()
+	if err := vk.ExportSnarkJS(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := vk.ImportSnarkJS(strings.NewReader(buf.String())); err != nil {
+		t.Fatal(err)
+	}
+	if err := vk.ImportSnarkJS(strings.NewReader(strings.Replace(buf.String(), `"nPublic": 2`, `"nPublic": 3`, 1))); err == nil {
+		t.Fatal("importing a verifying key with a wrong nPublic should fail")
+	}
+}
+
 func GenG1() gopter.Gen {
 	_, _, g1GenAff, _ := curve.Generators()
 	return func(genParams *gopter.GenParameters) *gopter.GenResult {
@@ -0,0 +1,160 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package plonk_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/polynomial/mockcommitment"
+	"github.com/consensys/gnark-crypto/polynomial"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/internal/backend/circuits"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+
+	bn254plonk "github.com/consensys/gnark/internal/backend/bn254/plonk"
+)
+
+// digest is the commitment of mockDigestScheme, it has the value of
+// mockcommitment.MockDigest but, unlike it, its value is encoded
+type digest struct {
+	d fr.Element
+}
+
+func (d *digest) WriteTo(w io.Writer) (int64, error) {
+	b := d.d.Bytes()
+	n, err := w.Write(b[:])
+	return int64(n), err
+}
+
+func (d *digest) ReadFrom(r io.Reader) (int64, error) {
+	var b [fr.Bytes]byte
+	n, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return int64(n), err
+	}
+	d.d.SetBytes(b[:])
+	return int64(n), nil
+}
+
+func (d *digest) Bytes() []byte {
+	b := d.d.Bytes()
+	return b[:]
+}
+
+// mockDigestScheme is mockcommitment.Scheme with commitments which survive a round
+// trip serialization, so that a decoded proof can be verified
+type mockDigestScheme struct {
+	mockcommitment.Scheme
+}
+
+func (s *mockDigestScheme) Commit(p polynomial.Polynomial) polynomial.Digest {
+	_p := p.(bn254.Polynomial)
+	var res digest
+	res.d.Set(&_p[0])
+	return &res
+}
+
+func TestSerialization(t *testing.T) {
+	circuit := circuits.Circuits["reference_small"]
+	spr, err := frontend.Compile(curve.ID, backend.PLONK, circuit.Circuit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicData, err := plonk.Setup(spr, &mockDigestScheme{}, circuit.Good)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := plonk.Prove(spr, publicData, circuit.Good)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, raw := range []bool{false, true} {
+		var buf bytes.Buffer
+
+		// public data
+		var written int64
+		if raw {
+			written, err = publicData.WriteRawTo(&buf)
+		} else {
+			written, err = publicData.WriteTo(&buf)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		reconstructedPublicData := &bn254plonk.PublicRaw{CommitmentScheme: &mockDigestScheme{}}
+		read, err := reconstructedPublicData.ReadFrom(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != read {
+			t.Fatal("didn't read same number of bytes we wrote")
+		}
+		if !reflect.DeepEqual(publicData, reconstructedPublicData) {
+			t.Fatal("round trip serialization of the public data failed")
+		}
+
+		// proof
+		if raw {
+			written, err = proof.WriteRawTo(&buf)
+		} else {
+			written, err = proof.WriteTo(&buf)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		reconstructedProof := bn254plonk.NewProofRaw(reconstructedPublicData.CommitmentScheme)
+		read, err = reconstructedProof.ReadFrom(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != read {
+			t.Fatal("didn't read same number of bytes we wrote")
+		}
+		if !reflect.DeepEqual(proof, reconstructedProof) {
+			t.Fatal("round trip serialization of the proof failed")
+		}
+
+		if err := plonk.Verify(reconstructedProof, reconstructedPublicData, circuit.Good); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSerializationErrors(t *testing.T) {
+	// the commitment scheme and the commitments are not encoded with their types
+	var publicData bn254plonk.PublicRaw
+	if _, err := publicData.ReadFrom(bytes.NewReader(nil)); err == nil {
+		t.Fatal("decoding public data without commitment scheme should fail")
+	}
+	var proof bn254plonk.ProofRaw
+	if _, err := proof.ReadFrom(bytes.NewReader(nil)); err == nil {
+		t.Fatal("decoding a proof without instantiated commitments should fail")
+	}
+	if _, err := proof.WriteTo(&bytes.Buffer{}); err == nil {
+		t.Fatal("encoding a proof without commitments should fail")
+	}
+}
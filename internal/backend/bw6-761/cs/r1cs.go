@@ -17,6 +17,7 @@
 package cs
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -25,16 +26,17 @@ import (
 
 	"github.com/fxamacker/cbor/v2"
 
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/hint"
 	"github.com/consensys/gnark/internal/backend/compiled"
-	"github.com/consensys/gnark/internal/backend/ioutils"
 
 	"github.com/consensys/gnark-crypto/ecc"
 
 	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
 )
 
-// ErrUnsatisfiedConstraint can be generated when solving a R1CS
-var ErrUnsatisfiedConstraint = errors.New("constraint is not satisfied")
+// errComputationalConstraint describes a constraint computing a wire which doesn't hold once solved
+const errComputationalConstraint = "couldn't solve computational constraint. May happen: div by 0 or no inverse found"
 
 // R1CS decsribes a set of R1CS constraint
 type R1CS struct {
@@ -78,22 +80,47 @@ func (r1cs *R1CS) SetLoggerOutput(w io.Writer) {
 	r1cs.loggerOut = w
 }
 
-// WriteTo encodes R1CS into provided io.Writer using cbor
+// WriteTo encodes R1CS into provided io.Writer: the cbor encoding of the R1CS is framed
+// with a header describing it (see compiled.WriteFrame)
 func (r1cs *R1CS) WriteTo(w io.Writer) (int64, error) {
-	_w := ioutils.WriterCounter{W: w} // wraps writer to count the bytes written
-	encoder := cbor.NewEncoder(&_w)
+	var payload bytes.Buffer
+	encoder := cbor.NewEncoder(&payload)
 
 	// encode our object
-	err := encoder.Encode(r1cs)
-	return _w.N, err
+	if err := encoder.Encode(r1cs); err != nil {
+		return 0, err
+	}
+
+	header := compiled.Header{
+		CurveID:             r1cs.CurveID(),
+		BackendID:           backend.GROTH16,
+		NbConstraints:       uint64(r1cs.NbConstraints),
+		NbInternalVariables: uint64(r1cs.NbInternalVariables),
+		NbSecretVariables:   uint64(r1cs.NbSecretVariables),
+		NbPublicVariables:   uint64(r1cs.NbPublicVariables),
+	}
+	return compiled.WriteFrame(w, header, payload.Bytes())
 }
 
-// ReadFrom attempts to decode R1CS from io.Reader using cbor
+// ReadFrom attempts to decode R1CS from io.Reader (see WriteTo)
+//
+// The header must describe a R1CS over the curve of r1cs.
 func (r1cs *R1CS) ReadFrom(r io.Reader) (int64, error) {
-	decoder := cbor.NewDecoder(r)
+	header, payload, n, err := compiled.ReadFrame(r)
+	if err != nil {
+		return n, err
+	}
+	if err := header.Check(r1cs.CurveID(), backend.GROTH16); err != nil {
+		return n, err
+	}
 
-	err := decoder.Decode(r1cs)
-	return int64(decoder.NumBytesRead()), err
+	if err := cbor.Unmarshal(payload, r1cs); err != nil {
+		return n, err
+	}
+	if err := header.CheckCounts(r1cs.NbConstraints, r1cs.NbInternalVariables, r1cs.NbSecretVariables, r1cs.NbPublicVariables); err != nil {
+		return n, err
+	}
+	return n, nil
 }
 
 // IsSolved returns nil if given witness solves the R1CS and error otherwise
@@ -113,6 +140,12 @@ func (r1cs *R1CS) IsSolved(witness []fr.Element) error {
 // wireValues =  [publicWires | secretWires | internalWires ]
 // witness = [publicWires | secretWires] (without the ONE_WIRE !)
 func (r1cs *R1CS) Solve(witness []fr.Element, a, b, c, wireValues []fr.Element) error {
+	return r1cs.solve(witness, a, b, c, wireValues, nil)
+}
+
+// solve implements Solve: if unsatisfied is not nil, the solver doesn't stop at the first
+// constraint which doesn't hold, but records it in unsatisfied (see SolveDiagnostic)
+func (r1cs *R1CS) solve(witness []fr.Element, a, b, c, wireValues []fr.Element, unsatisfied *[]compiled.UnsatisfiedConstraint) error {
 	if len(witness) != int(r1cs.NbPublicVariables-1+r1cs.NbSecretVariables) { // - 1 for ONE_WIRE
 		return fmt.Errorf("invalid witness size, got %d, expected %d = %d (public - ONE_WIRE) + %d (secret)", len(witness), int(r1cs.NbPublicVariables-1+r1cs.NbSecretVariables), r1cs.NbPublicVariables-1, r1cs.NbSecretVariables)
 	}
@@ -130,16 +163,32 @@ func (r1cs *R1CS) Solve(witness []fr.Element, a, b, c, wireValues []fr.Element)
 		wireInstantiated[i+1] = true
 	}
 
+	// the public outputs are computed by the solver
+	for i := 0; i < len(r1cs.Outputs); i++ {
+		wireInstantiated[r1cs.Outputs[i].WireID] = false
+	}
+
 	// now that we know all inputs are set, defer log printing once all wireValues are computed
 	// (or sooner, if a constraint is not satisfied)
 	defer r1cs.printLogs(wireValues, wireInstantiated)
 
+	// wires computed by hints are solved when they are first needed
+	mHints := make(map[int]*compiled.Hint, len(r1cs.Hints))
+	for i := 0; i < len(r1cs.Hints); i++ {
+		mHints[r1cs.Hints[i].WireID] = &r1cs.Hints[i]
+	}
+
 	// check if there is an inconsistant constraint
 	var check fr.Element
 
 	// Loop through computational constraints (the one wwe need to solve and compute a wire in)
 	for i := 0; i < int(r1cs.NbCOConstraints); i++ {
 
+		// solve the hints the constraint depends on
+		if err := r1cs.solveHintWires(&r1cs.Constraints[i], mHints, wireInstantiated, wireValues); err != nil {
+			return err
+		}
+
 		// solve the constraint, this will compute the missing wire of the gate
 		r1cs.solveR1C(&r1cs.Constraints[i], wireInstantiated, wireValues)
 
@@ -149,10 +198,28 @@ func (r1cs *R1CS) Solve(witness []fr.Element, a, b, c, wireValues []fr.Element)
 
 		check.Mul(&a[i], &b[i])
 		if !check.Equal(&c[i]) {
-			return fmt.Errorf("%w: %s", ErrUnsatisfiedConstraint, "couldn't solve computational constraint. May happen: div by 0 or no inverse found")
+			if unsatisfied == nil {
+				return fmt.Errorf("%w: %s", backend.ErrUnsatisfiedConstraint, errComputationalConstraint)
+			}
+			*unsatisfied = append(*unsatisfied, r1cs.unsatisfiedR1C(i, a[i], b[i], c[i], wireValues, wireInstantiated))
 		}
 	}
 
+	// solve the remaining hints (the ones which appear only in assertions)
+	for i := 0; i < len(r1cs.Hints); i++ {
+		if !wireInstantiated[r1cs.Hints[i].WireID] {
+			if err := r1cs.solveHint(&r1cs.Hints[i], mHints, wireInstantiated, wireValues); err != nil {
+				return err
+			}
+		}
+	}
+
+	// set the public outputs, before checking the assertions binding them to their values
+	for i := 0; i < len(r1cs.Outputs); i++ {
+		wireValues[r1cs.Outputs[i].WireID] = wireValues[r1cs.Outputs[i].ValueID]
+		wireInstantiated[r1cs.Outputs[i].WireID] = true
+	}
+
 	// Loop through the assertions -- here all wireValues should be instantiated
 	// if a[i] * b[i] != c[i]; it means the constraint is not satisfied
 	for i := int(r1cs.NbCOConstraints); i < len(r1cs.Constraints); i++ {
@@ -164,15 +231,139 @@ func (r1cs *R1CS) Solve(witness []fr.Element, a, b, c, wireValues []fr.Element)
 		// check that the constraint is satisfied
 		check.Mul(&a[i], &b[i])
 		if !check.Equal(&c[i]) {
-			debugInfo := r1cs.DebugInfo[i-int(r1cs.NbCOConstraints)]
-			debugInfoStr := r1cs.logValue(debugInfo, wireValues, wireInstantiated)
-			return fmt.Errorf("%w: %s", ErrUnsatisfiedConstraint, debugInfoStr)
+			if unsatisfied == nil {
+				debugInfo := r1cs.DebugInfo[i-int(r1cs.NbCOConstraints)]
+				debugInfoStr := r1cs.logValue(debugInfo, wireValues, wireInstantiated)
+				return fmt.Errorf("%w: %s", backend.ErrUnsatisfiedConstraint, debugInfoStr)
+			}
+			*unsatisfied = append(*unsatisfied, r1cs.unsatisfiedR1C(i, a[i], b[i], c[i], wireValues, wireInstantiated))
 		}
 	}
 
 	return nil
 }
 
+// SolveWires solves the R1CS and returns the values of all the wires
+// witness = [publicWires | secretWires] (without the ONE_WIRE !)
+// the result is [ONE_WIRE | publicWires | secretWires | internalWires]
+func (r1cs *R1CS) SolveWires(witness []fr.Element) ([]fr.Element, error) {
+	a := make([]fr.Element, r1cs.NbConstraints)
+	b := make([]fr.Element, r1cs.NbConstraints)
+	c := make([]fr.Element, r1cs.NbConstraints)
+	wireValues := make([]fr.Element, r1cs.NbInternalVariables+r1cs.NbPublicVariables+r1cs.NbSecretVariables)
+	if err := r1cs.Solve(witness, a, b, c, wireValues); err != nil {
+		return nil, err
+	}
+	return wireValues, nil
+}
+
+// SolveDiagnostic solves the R1CS like SolveWires, but doesn't stop at the first constraint which doesn't hold:
+// it returns the values of all the wires and the constraints which don't hold (if any)
+// witness = [publicWires | secretWires] (without the ONE_WIRE !)
+func (r1cs *R1CS) SolveDiagnostic(witness []fr.Element) ([]fr.Element, []compiled.UnsatisfiedConstraint, error) {
+	a := make([]fr.Element, r1cs.NbConstraints)
+	b := make([]fr.Element, r1cs.NbConstraints)
+	c := make([]fr.Element, r1cs.NbConstraints)
+	wireValues := make([]fr.Element, r1cs.NbInternalVariables+r1cs.NbPublicVariables+r1cs.NbSecretVariables)
+	var unsatisfied []compiled.UnsatisfiedConstraint
+	if err := r1cs.solve(witness, a, b, c, wireValues, &unsatisfied); err != nil {
+		return nil, nil, err
+	}
+	return wireValues, unsatisfied, nil
+}
+
+// unsatisfiedR1C describes the i-th constraint, which doesn't hold: a * b != c
+func (r1cs *R1CS) unsatisfiedR1C(i int, a, b, c fr.Element, wireValues []fr.Element, wireInstantiated []bool) compiled.UnsatisfiedConstraint {
+	res := compiled.UnsatisfiedConstraint{
+		Assertion: i >= int(r1cs.NbCOConstraints),
+		ID:        i,
+	}
+	a.ToBigIntRegular(&res.L)
+	b.ToBigIntRegular(&res.R)
+	c.ToBigIntRegular(&res.O)
+
+	if res.Assertion {
+		res.DebugInfo = r1cs.logValue(r1cs.DebugInfo[i-int(r1cs.NbCOConstraints)], wireValues, wireInstantiated)
+	} else {
+		res.DebugInfo = errComputationalConstraint
+	}
+
+	r := &r1cs.Constraints[i]
+	seen := make(map[int]struct{})
+	for _, l := range [3]compiled.LinearExpression{r.L, r.R, r.O} {
+		for _, t := range l {
+			if _, ok := seen[t.VariableID()]; !ok {
+				seen[t.VariableID()] = struct{}{}
+				res.Wires = append(res.Wires, t.VariableID())
+			}
+		}
+	}
+	return res
+}
+
+// SolveOutputs solves the R1CS and returns the values of the public outputs (see gnark:",output")
+// witness = [publicWires | secretWires] (without the ONE_WIRE !), the values of the public outputs are ignored
+func (r1cs *R1CS) SolveOutputs(witness []fr.Element) ([]fr.Element, error) {
+	wireValues, err := r1cs.SolveWires(witness)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]fr.Element, len(r1cs.Outputs))
+	for i := 0; i < len(r1cs.Outputs); i++ {
+		res[i] = wireValues[r1cs.Outputs[i].WireID]
+	}
+	return res, nil
+}
+
+// SolveBigInt solves the R1CS like SolveWires, or like SolveDiagnostic if diagnostic is set,
+// on big.Int values (see internal/backend/bigint)
+func (r1cs *R1CS) SolveBigInt(witness []big.Int, diagnostic bool) ([]big.Int, []compiled.UnsatisfiedConstraint, error) {
+	var wireValues []fr.Element
+	var unsatisfied []compiled.UnsatisfiedConstraint
+	var err error
+	if diagnostic {
+		wireValues, unsatisfied, err = r1cs.SolveDiagnostic(toElements(witness))
+	} else {
+		wireValues, err = r1cs.SolveWires(toElements(witness))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return toBigInts(wireValues), unsatisfied, nil
+}
+
+// SolveOutputsBigInt solves the R1CS like SolveOutputs, on big.Int values (see internal/backend/bigint)
+func (r1cs *R1CS) SolveOutputsBigInt(witness []big.Int) ([]big.Int, error) {
+	outputs, err := r1cs.SolveOutputs(toElements(witness))
+	if err != nil {
+		return nil, err
+	}
+	return toBigInts(outputs), nil
+}
+
+// ToCompiled returns the curve independent R1CS and its coefficients (see internal/backend/bigint)
+func (r1cs *R1CS) ToCompiled() (*compiled.R1CS, []big.Int) {
+	return &r1cs.R1CS, toBigInts(r1cs.Coefficients)
+}
+
+// toElements returns the values, reduced modulo the scalar field
+func toElements(values []big.Int) []fr.Element {
+	res := make([]fr.Element, len(values))
+	for i := 0; i < len(values); i++ {
+		res[i].SetBigInt(&values[i])
+	}
+	return res
+}
+
+// toBigInts returns the values in regular form
+func toBigInts(values []fr.Element) []big.Int {
+	res := make([]big.Int, len(values))
+	for i := 0; i < len(values); i++ {
+		values[i].ToBigIntRegular(&res[i])
+	}
+	return res
+}
+
 func (r1cs *R1CS) logValue(entry compiled.LogEntry, wireValues []fr.Element, wireInstantiated []bool) string {
 	var toResolve []interface{}
 	for j := 0; j < len(entry.ToResolve); j++ {
@@ -255,6 +446,59 @@ func instantiateR1C(r *compiled.R1C, r1cs *R1CS, wireValues []fr.Element) (a, b,
 	return
 }
 
+// solveHintWires solves the wires of r which are computed by a hint and not instantiated yet
+func (r1cs *R1CS) solveHintWires(r *compiled.R1C, mHints map[int]*compiled.Hint, wireInstantiated []bool, wireValues []fr.Element) error {
+	for _, l := range [3]compiled.LinearExpression{r.L, r.R, r.O} {
+		for _, t := range l {
+			cID := t.VariableID()
+			if h, ok := mHints[cID]; ok && !wireInstantiated[cID] {
+				if err := r1cs.solveHint(h, mHints, wireInstantiated, wireValues); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// solveHint computes the wire h.WireID by calling the hint function on the values of its inputs
+// if an input is computed by another hint, it is solved first
+func (r1cs *R1CS) solveHint(h *compiled.Hint, mHints map[int]*compiled.Hint, wireInstantiated []bool, wireValues []fr.Element) error {
+	f, ok := hint.Lookup(h.ID)
+	if !ok {
+		return fmt.Errorf("%w: id %d", hint.ErrNotFound, h.ID)
+	}
+
+	inputs := make([]*big.Int, len(h.Inputs))
+	for i := 0; i < len(h.Inputs); i++ {
+		var v fr.Element
+		for _, t := range h.Inputs[i] {
+			cID := t.VariableID()
+			if !wireInstantiated[cID] {
+				_h, ok := mHints[cID]
+				if !ok {
+					return fmt.Errorf("hint input (wire %d) is not instantiated", cID)
+				}
+				if err := r1cs.solveHint(_h, mHints, wireInstantiated, wireValues); err != nil {
+					return err
+				}
+			}
+			r1cs.AddTerm(&v, t, wireValues[cID])
+		}
+		inputs[i] = new(big.Int)
+		v.ToBigIntRegular(inputs[i])
+	}
+
+	var result big.Int
+	if err := f(r1cs.CurveID(), inputs, &result); err != nil {
+		return err
+	}
+	wireValues[h.WireID].SetBigInt(&result)
+	wireInstantiated[h.WireID] = true
+
+	return nil
+}
+
 // solveR1c computes a wire by solving a r1cs
 // the function searches for the unset wire (either the unset wire is
 // alone, or it can be computed without ambiguity using the other computed wires
@@ -0,0 +1,294 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by gnark DO NOT EDIT
+
+package plonk
+
+import (
+	"errors"
+	"io"
+
+	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/polynomial"
+	"github.com/consensys/gnark-crypto/polynomial"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bw6-761"
+
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+
+	"github.com/consensys/gnark-crypto/ecc/bw6-761/fr/fft"
+)
+
+// rawWriter is implemented by the commitment schemes, commitments and opening proofs
+// which have an encoding without point compression
+type rawWriter interface {
+	WriteRawTo(w io.Writer) (int64, error)
+}
+
+// WriteTo writes binary encoding of the PublicRaw to writer
+// CommitmentScheme | Ql | Qr | Qm | Qo | Qk | DomainNum | DomainH | Shifter | LS1 | LS2 | LS3 | CS1 | CS2 | CS3 | Permutation
+// the polynomials and the permutation are prefixed by their size (uint64)
+// use WriteRawTo(...) to encode the commitment scheme without point compression
+func (publicData *PublicRaw) WriteTo(w io.Writer) (n int64, err error) {
+	return publicData.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the PublicRaw to writer
+// the commitment scheme is encoded without point compression, if it supports it
+// use WriteTo(...) to encode the commitment scheme with point compression
+func (publicData *PublicRaw) WriteRawTo(w io.Writer) (n int64, err error) {
+	return publicData.writeTo(w, true)
+}
+
+func (publicData *PublicRaw) writeTo(w io.Writer, raw bool) (int64, error) {
+	n, err := writeObject(w, publicData.CommitmentScheme, raw)
+	if err != nil {
+		return n, err
+	}
+
+	enc := curve.NewEncoder(w)
+	for _, p := range []bw6761.Polynomial{publicData.Ql, publicData.Qr, publicData.Qm, publicData.Qo, publicData.Qk} {
+		if err := encodePolynomial(enc, p); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+
+	for _, d := range []*fft.Domain{publicData.DomainNum, publicData.DomainH} {
+		if d == nil {
+			return n + enc.BytesWritten(), errors.New("fft domain is not set")
+		}
+		m, err := d.WriteTo(w)
+		n += m
+		if err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+
+	toEncode := []interface{}{&publicData.Shifter[0], &publicData.Shifter[1]}
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+
+	for _, p := range []bw6761.Polynomial{publicData.LS1, publicData.LS2, publicData.LS3, publicData.CS1, publicData.CS2, publicData.CS3} {
+		if err := encodePolynomial(enc, p); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+
+	if err := enc.Encode(uint64(len(publicData.Permutation))); err != nil {
+		return n + enc.BytesWritten(), err
+	}
+	for _, p := range publicData.Permutation {
+		if err := enc.Encode(uint64(p)); err != nil {
+			return n + enc.BytesWritten(), err
+		}
+	}
+
+	return n + enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode a PublicRaw from reader
+// PublicRaw must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+//
+// The type of the commitment scheme is not encoded: publicData.CommitmentScheme must be set to an
+// instance of the scheme used at setup, which is decoded in place.
+func (publicData *PublicRaw) ReadFrom(r io.Reader) (int64, error) {
+	if publicData.CommitmentScheme == nil {
+		return 0, errors.New("the commitment scheme must be set before decoding the public data")
+	}
+	n, err := publicData.CommitmentScheme.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+
+	dec := curve.NewDecoder(r)
+	for _, p := range []*bw6761.Polynomial{&publicData.Ql, &publicData.Qr, &publicData.Qm, &publicData.Qo, &publicData.Qk} {
+		if err := decodePolynomial(dec, p); err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+
+	publicData.DomainNum, publicData.DomainH = &fft.Domain{}, &fft.Domain{}
+	for _, d := range []*fft.Domain{publicData.DomainNum, publicData.DomainH} {
+		m, err := d.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+
+	toDecode := []interface{}{&publicData.Shifter[0], &publicData.Shifter[1]}
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+
+	for _, p := range []*bw6761.Polynomial{&publicData.LS1, &publicData.LS2, &publicData.LS3, &publicData.CS1, &publicData.CS2, &publicData.CS3} {
+		if err := decodePolynomial(dec, p); err != nil {
+			return n + dec.BytesRead(), err
+		}
+	}
+
+	var size uint64
+	if err := dec.Decode(&size); err != nil {
+		return n + dec.BytesRead(), err
+	}
+	publicData.Permutation = make([]int, size)
+	for i := range publicData.Permutation {
+		var p uint64
+		if err := dec.Decode(&p); err != nil {
+			return n + dec.BytesRead(), err
+		}
+		publicData.Permutation[i] = int(p)
+	}
+
+	return n + dec.BytesRead(), nil
+}
+
+// NewProofRaw returns an empty proof, which commitments and opening proofs have the types of
+// the ones produced by the commitment scheme, so that it can be decoded with ReadFrom
+func NewProofRaw(polynomialCommitment polynomial.CommitmentScheme) *ProofRaw {
+	var zero fr.Element
+	p := bw6761.Polynomial{zero}
+
+	var proof ProofRaw
+	for i := 0; i < len(proof.CommitmentsLROZH); i++ {
+		proof.CommitmentsLROZH[i] = polynomialCommitment.Commit(p)
+	}
+	proof.BatchOpenings = polynomialCommitment.BatchOpenSinglePoint(&zero, []bw6761.Polynomial{p})
+	proof.OpeningZShift = polynomialCommitment.Open(&zero, p)
+	return &proof
+}
+
+// WriteTo writes binary encoding of the ProofRaw to writer
+// LROZH | ZShift | CommitmentsLROZH | BatchOpenings | OpeningZShift
+// use WriteRawTo(...) to encode the commitments and opening proofs without point compression
+func (proof *ProofRaw) WriteTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the ProofRaw to writer
+// the commitments and opening proofs are encoded without point compression, if they support it
+// use WriteTo(...) to encode the commitments and opening proofs with point compression
+func (proof *ProofRaw) WriteRawTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, true)
+}
+
+func (proof *ProofRaw) writeTo(w io.Writer, raw bool) (int64, error) {
+	enc := curve.NewEncoder(w)
+	for i := 0; i < len(proof.LROZH); i++ {
+		if err := enc.Encode(&proof.LROZH[i]); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	if err := enc.Encode(&proof.ZShift); err != nil {
+		return enc.BytesWritten(), err
+	}
+
+	n := enc.BytesWritten()
+	for i := 0; i < len(proof.CommitmentsLROZH); i++ {
+		m, err := writeObject(w, proof.CommitmentsLROZH[i], raw)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	m, err := writeObject(w, proof.BatchOpenings, raw)
+	n += m
+	if err != nil {
+		return n, err
+	}
+	m, err = writeObject(w, proof.OpeningZShift, raw)
+	return n + m, err
+}
+
+// ReadFrom attempts to decode a ProofRaw from reader
+// ProofRaw must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+//
+// The types of the commitments and opening proofs are not encoded: they must be instantiated
+// before decoding (see NewProofRaw).
+func (proof *ProofRaw) ReadFrom(r io.Reader) (int64, error) {
+	toRead := make([]io.ReaderFrom, 0, len(proof.CommitmentsLROZH)+2)
+	for i := 0; i < len(proof.CommitmentsLROZH); i++ {
+		toRead = append(toRead, proof.CommitmentsLROZH[i])
+	}
+	toRead = append(toRead, proof.BatchOpenings, proof.OpeningZShift)
+	for _, v := range toRead {
+		if v == nil {
+			return 0, errors.New("the commitments and opening proofs must be instantiated before decoding the proof (see NewProofRaw)")
+		}
+	}
+
+	dec := curve.NewDecoder(r)
+	for i := 0; i < len(proof.LROZH); i++ {
+		if err := dec.Decode(&proof.LROZH[i]); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	if err := dec.Decode(&proof.ZShift); err != nil {
+		return dec.BytesRead(), err
+	}
+
+	n := dec.BytesRead()
+	for _, v := range toRead {
+		m, err := v.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// writeObject writes the encoding of v, without point compression if raw is set and v supports it
+func writeObject(w io.Writer, v io.WriterTo, raw bool) (int64, error) {
+	if v == nil {
+		return 0, errors.New("can't encode a nil commitment scheme, commitment or opening proof")
+	}
+	if _v, ok := v.(rawWriter); ok && raw {
+		return _v.WriteRawTo(w)
+	}
+	return v.WriteTo(w)
+}
+
+// encodePolynomial writes the size of p (uint64) followed by its coefficients
+func encodePolynomial(enc *curve.Encoder, p bw6761.Polynomial) error {
+	if err := enc.Encode(uint64(len(p))); err != nil {
+		return err
+	}
+	for i := 0; i < len(p); i++ {
+		if err := enc.Encode(&p[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodePolynomial reads a polynomial encoded by encodePolynomial
+func decodePolynomial(dec *curve.Decoder, p *bw6761.Polynomial) error {
+	var size uint64
+	if err := dec.Decode(&size); err != nil {
+		return err
+	}
+	*p = make(bw6761.Polynomial, size)
+	for i := 0; i < len(*p); i++ {
+		if err := dec.Decode(&(*p)[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
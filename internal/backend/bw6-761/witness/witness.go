@@ -20,6 +20,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"io"
+	"math/big"
 	"reflect"
 
 	"github.com/consensys/gnark/frontend"
@@ -79,6 +80,7 @@ func (witness *Witness) LimitReadFrom(r io.Reader, expectedSize int) (int64, err
 }
 
 // FromFullAssignment extracts the full witness [ public | secret ]
+// the public outputs which are not assigned are set to 0 (they are computed by the solver)
 func (witness *Witness) FromFullAssignment(w frontend.Circuit) error {
 	nbSecret, nbPublic := count(w)
 
@@ -96,13 +98,19 @@ func (witness *Witness) FromFullAssignment(w frontend.Circuit) error {
 
 		val := frontend.GetAssignedValue(v)
 		if val == nil {
+			// the public outputs are computed by the solver
+			if visibility == compiled.Output {
+				(*witness)[j].SetZero()
+				j++
+				return nil
+			}
 			return errors.New("variable " + name + " not assigned")
 		}
 
 		if visibility == compiled.Secret {
 			(*witness)[i].SetInterface(val)
 			i++
-		} else if visibility == compiled.Public {
+		} else if visibility == compiled.Public || visibility == compiled.Output {
 			(*witness)[j].SetInterface(val)
 			j++
 		}
@@ -124,7 +132,7 @@ func (witness *Witness) FromPublicAssignment(w frontend.Circuit) error {
 	var j int // index for public variables
 
 	var collectHandler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
-		if visibility == compiled.Public {
+		if visibility == compiled.Public || visibility == compiled.Output {
 			v := tInput.Interface().(frontend.Variable)
 			val := frontend.GetAssignedValue(v)
 			if val == nil {
@@ -138,11 +146,49 @@ func (witness *Witness) FromPublicAssignment(w frontend.Circuit) error {
 	return parser.Visit(w, "", compiled.Unset, collectHandler, reflect.TypeOf(frontend.Variable{}))
 }
 
+// Encoding encodes the witnesses of the curve from and to big.Int values (see internal/backend/bigint)
+type Encoding struct{}
+
+// FromFullAssignment returns the full witness [ public | secret ] (see Witness.FromFullAssignment)
+func (Encoding) FromFullAssignment(w frontend.Circuit) ([]big.Int, error) {
+	var witness Witness
+	if err := witness.FromFullAssignment(w); err != nil {
+		return nil, err
+	}
+	values := make([]big.Int, len(witness))
+	for i := 0; i < len(witness); i++ {
+		witness[i].ToBigIntRegular(&values[i])
+	}
+	return values, nil
+}
+
+// WriteTo encodes the values, reduced modulo the scalar field, to writer (see Witness.WriteTo)
+func (Encoding) WriteTo(w io.Writer, values []big.Int) (int64, error) {
+	witness := make(Witness, len(values))
+	for i := 0; i < len(values); i++ {
+		witness[i].SetBigInt(&values[i])
+	}
+	return witness.WriteTo(w)
+}
+
+// LimitReadFrom decodes a witness of expectedSize values from reader (see Witness.LimitReadFrom)
+func (Encoding) LimitReadFrom(r io.Reader, expectedSize int) ([]big.Int, error) {
+	var witness Witness
+	if _, err := witness.LimitReadFrom(r, expectedSize); err != nil {
+		return nil, err
+	}
+	values := make([]big.Int, len(witness))
+	for i := 0; i < len(witness); i++ {
+		witness[i].ToBigIntRegular(&values[i])
+	}
+	return values, nil
+}
+
 func count(w frontend.Circuit) (nbSecret, nbPublic int) {
 	var collectHandler parser.LeafHandler = func(visibility compiled.Visibility, name string, tInput reflect.Value) error {
 		if visibility == compiled.Secret {
 			nbSecret++
-		} else if visibility == compiled.Public {
+		} else if visibility == compiled.Public || visibility == compiled.Output {
 			nbPublic++
 		}
 		return nil
@@ -10,7 +10,7 @@ type checkAssertEqualCircuit struct {
 	Y frontend.Variable `gnark:",public"`
 }
 
-func (circuit *checkAssertEqualCircuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *checkAssertEqualCircuit) Define(curveID ecc.ID, cs frontend.API) error {
 	cs.AssertIsEqual(circuit.X, circuit.Y)
 	c1 := cs.Add(circuit.X, circuit.Y)
 	cs.AssertIsEqual(c1, 6)
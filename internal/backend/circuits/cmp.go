@@ -0,0 +1,76 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+type cmpCircuit struct {
+	A, B frontend.Variable
+	Cmp  frontend.Variable `gnark:",public"`
+	Max  frontend.Variable `gnark:",public"`
+}
+
+func (circuit *cmpCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+
+	cmp := cs.Cmp(circuit.A, circuit.B, 8)
+	cs.AssertIsEqual(cmp, circuit.Cmp)
+
+	// A == B iff cmp == 0
+	cs.AssertIsEqual(cs.Add(cs.IsEqual(circuit.A, circuit.B), cs.Mul(cmp, cmp)), 1)
+
+	// branch on the comparison, without failing when A > B
+	max := cs.Select(cs.IsLessOrEqual(circuit.A, circuit.B, 8), circuit.B, circuit.A)
+	cs.AssertIsEqual(max, circuit.Max)
+
+	// comparisons with constants
+	cs.AssertIsEqual(cs.IsLess(max, 200, 8), 1)
+	cs.AssertIsEqual(cs.IsEqual(circuit.Max, 200), 0)
+
+	return nil
+}
+
+func init() {
+	// A > B, A < B and A == B
+	addCmpEntry("cmp", 42, 10, 1, 42)
+	addCmpEntry("cmp_less", 10, 42, -1, 42)
+	addCmpEntry("cmp_equal", 42, 42, 0, 42)
+
+	// the operands are range checked: without it, A = -1 (r - 1) would be less than 0
+	var circuit, good, bad, public cmpCircuit
+
+	good.A.Assign(1)
+	good.B.Assign(0)
+	good.Cmp.Assign(1)
+	good.Max.Assign(1)
+
+	bad.A.Assign(-1)
+	bad.B.Assign(0)
+	bad.Cmp.Assign(-1)
+	bad.Max.Assign(0)
+
+	public.Cmp.Assign(1)
+	public.Max.Assign(1)
+
+	addEntry("cmp_range", &circuit, &good, &bad, &public)
+}
+
+func addCmpEntry(name string, a, b, cmp, max int) {
+	var circuit, good, bad, public cmpCircuit
+
+	good.A.Assign(a)
+	good.B.Assign(b)
+	good.Cmp.Assign(cmp)
+	good.Max.Assign(max)
+
+	// the comparison is off by one
+	bad.A.Assign(a)
+	bad.B.Assign(b)
+	bad.Cmp.Assign(cmp - 1)
+	bad.Max.Assign(max)
+
+	public.Cmp.Assign(cmp)
+	public.Max.Assign(max)
+
+	addEntry(name, &circuit, &good, &bad, &public)
+}
@@ -0,0 +1,91 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+type divModCircuit struct {
+	A, B      frontend.Variable
+	Q, R      frontend.Variable `gnark:",public"`
+	Mod, Wrap frontend.Variable `gnark:",public"`
+}
+
+func (circuit *divModCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	q, r := cs.DivMod(circuit.A, circuit.B, 32)
+	cs.AssertIsEqual(q, circuit.Q)
+	cs.AssertIsEqual(r, circuit.R)
+
+	cs.AssertIsEqual(cs.Mod(circuit.A, 97), circuit.Mod)
+
+	// -b is r - b as an integer, which is odd since the field modulus r is odd
+	cs.AssertIsEqual(cs.Mod(cs.Sub(0, circuit.B), 2), circuit.Wrap)
+
+	return nil
+}
+
+// divModConstantCircuit divides a constant by a variable, and two constants
+type divModConstantCircuit struct {
+	B    frontend.Variable
+	Q, R frontend.Variable `gnark:",public"`
+}
+
+func (circuit *divModConstantCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	q, r := cs.DivMod(cs.Constant(1234567), circuit.B, 32)
+	cs.AssertIsEqual(q, circuit.Q)
+	cs.AssertIsEqual(r, circuit.R)
+
+	q, r = cs.DivMod(cs.Constant(1234567), cs.Constant(1000), 32)
+	cs.AssertIsEqual(q, 1234)
+	cs.AssertIsEqual(r, 567)
+
+	return nil
+}
+
+func init() {
+	// b = 0: the remainder can't be smaller than b
+	// a = 2^32 + 1 doesn't fit on 32 bits, but the quotient 1 and the remainder 2 of its division by 2^32 - 1 do
+	for name, bad := range map[string]*divModCircuit{
+		"divmod":          divModWitness(1234567, 1000, 1233, 1567),
+		"divmod_zero":     divModWitness(1234567, 0, 0, 1234567),
+		"divmod_overflow": divModWitness(1<<32+1, 1<<32-1, 1, 2),
+	} {
+		var circuit, public divModCircuit
+		public.Q.Assign(1234)
+		public.R.Assign(567)
+		public.Mod.Assign(1234567 % 97)
+		public.Wrap.Assign(1)
+		addEntry(name, &circuit, divModWitness(1234567, 1000, 1234, 567), bad, &public)
+	}
+
+	var circuit, good, bad, public divModConstantCircuit
+
+	good.B.Assign(1000)
+	good.Q.Assign(1234)
+	good.R.Assign(567)
+
+	bad.B.Assign(1000)
+	bad.Q.Assign(1233)
+	bad.R.Assign(1567)
+
+	public.Q.Assign(1234)
+	public.R.Assign(567)
+
+	addEntry("divmod_constant", &circuit, &good, &bad, &public)
+}
+
+// divModWitness returns a witness of divModCircuit dividing a by b, claiming the quotient q and the remainder r
+func divModWitness(a, b, q, r int) *divModCircuit {
+	var w divModCircuit
+	w.A.Assign(a)
+	w.B.Assign(b)
+	w.Q.Assign(q)
+	w.R.Assign(r)
+	w.Mod.Assign(a % 97)
+	if b != 0 && b%2 == 0 {
+		w.Wrap.Assign(1)
+	} else {
+		w.Wrap.Assign(0)
+	}
+	return &w
+}
@@ -1,7 +1,13 @@
 package circuits
 
 import (
+	"math/big"
+
 	"github.com/consensys/gnark-crypto/ecc"
+	frbls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	frbls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	frbn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	frbw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
 	"github.com/consensys/gnark/frontend"
 )
 
@@ -10,18 +16,8 @@ type expCircuit struct {
 	Y    frontend.Variable `gnark:",public"`
 }
 
-func (circuit *expCircuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
-	o := cs.Constant(1)
-	b := cs.ToBinary(circuit.E, 4)
-
-	var i int
-	for i < len(b) {
-		o = cs.Mul(o, o)
-		mu := cs.Mul(o, circuit.X)
-		o = cs.Select(b[len(b)-1-i], mu, o)
-		i++
-	}
-	cs.AssertIsEqual(circuit.Y, o)
+func (circuit *expCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	cs.AssertIsEqual(circuit.Y, cs.Exp(circuit.X, circuit.E, 4))
 	return nil
 }
 
@@ -40,3 +36,48 @@ func init() {
 
 	addEntry("expo", &circuit, &good, &bad, &public)
 }
+
+type expConstantCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (circuit *expConstantCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	cs.AssertIsEqual(circuit.Y, cs.ExpConstant(circuit.X, 607))
+
+	// x^(r-2) is the inverse of x
+	var e big.Int
+	switch curveID {
+	case ecc.BN254:
+		e.Set(frbn254.Modulus())
+	case ecc.BLS12_381:
+		e.Set(frbls12381.Modulus())
+	case ecc.BLS12_377:
+		e.Set(frbls12377.Modulus())
+	case ecc.BW6_761:
+		e.Set(frbw6761.Modulus())
+	default:
+		panic("not implemented")
+	}
+	e.Sub(&e, big.NewInt(2))
+	cs.AssertIsEqual(cs.Mul(circuit.X, cs.ExpConstant(circuit.X, e)), 1)
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public expConstantCircuit
+
+	var y, yBad big.Int
+	y.Exp(big.NewInt(3), big.NewInt(607), nil)
+	yBad.Add(&y, big.NewInt(1))
+
+	good.X.Assign(3)
+	good.Y.Assign(y)
+
+	bad.X.Assign(3)
+	bad.Y.Assign(yBad)
+
+	public.Y.Assign(y)
+
+	addEntry("expo_constant", &circuit, &good, &bad, &public)
+}
@@ -0,0 +1,40 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend/hint"
+	"github.com/consensys/gnark/frontend"
+)
+
+type hintCircuit struct {
+	A frontend.Variable
+	B frontend.Variable `gnark:",public"`
+}
+
+func (circuit *hintCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+
+	// decompose A using hints, and constrain the decomposition
+	bits := make([]frontend.Variable, 4)
+	for i := 0; i < len(bits); i++ {
+		bits[i] = cs.NewHint(hint.IthBit, circuit.A, i)
+	}
+	cs.AssertIsEqual(cs.FromBinary(bits...), circuit.A)
+
+	cs.AssertIsEqual(cs.Mul(bits[0], bits[3]), circuit.B)
+
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public hintCircuit
+
+	good.A.Assign(9)
+	good.B.Assign(1)
+
+	bad.A.Assign(8)
+	bad.B.Assign(1)
+
+	public.B.Assign(1)
+
+	addEntry("hint", &circuit, &good, &bad, &public)
+}
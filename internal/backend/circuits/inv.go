@@ -9,7 +9,7 @@ type invCircuit struct {
 	X, Y, Z frontend.Variable
 }
 
-func (circuit *invCircuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *invCircuit) Define(curveID ecc.ID, cs frontend.API) error {
 	m := cs.Mul(circuit.X, circuit.Y)
 	u := cs.Inverse(circuit.Y)
 	v := cs.Mul(m, u)
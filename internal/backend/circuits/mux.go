@@ -0,0 +1,54 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+type muxCircuit struct {
+	Sel    frontend.Variable
+	B0, B1 frontend.Variable
+	Values [5]frontend.Variable
+	Get    frontend.Variable `gnark:",public"`
+	Lookup frontend.Variable `gnark:",public"`
+}
+
+func (circuit *muxCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+
+	cs.AssertIsEqual(cs.ArrayGet(circuit.Values[:], circuit.Sel), circuit.Get)
+
+	// selection among constants
+	cs.AssertIsEqual(cs.Mux(circuit.Sel, 10, 11, 12, 13, 14), cs.Add(circuit.Sel, 10))
+
+	cs.AssertIsEqual(cs.Lookup2(circuit.B0, circuit.B1, circuit.Values[0], 42, circuit.Values[2], circuit.Values[3]), circuit.Lookup)
+
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public muxCircuit
+
+	good.Sel.Assign(3)
+	good.B0.Assign(0)
+	good.B1.Assign(1)
+	for i := 0; i < len(good.Values); i++ {
+		good.Values[i].Assign(i + 1)
+	}
+	good.Get.Assign(4)
+	good.Lookup.Assign(3)
+
+	// out of range selector
+	bad.Sel.Assign(5)
+	bad.B0.Assign(0)
+	bad.B1.Assign(1)
+	for i := 0; i < len(bad.Values); i++ {
+		bad.Values[i].Assign(i + 1)
+	}
+	bad.Get.Assign(5)
+	bad.Lookup.Assign(3)
+
+	public.Get.Assign(4)
+	public.Lookup.Assign(3)
+
+	addEntry("mux", &circuit, &good, &bad, &public)
+}
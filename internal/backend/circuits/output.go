@@ -0,0 +1,35 @@
+package circuits
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+)
+
+type outputCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+	Z frontend.Variable `gnark:",output"`
+}
+
+func (circuit *outputCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+	cs.AssertIsEqual(cs.Mul(circuit.X, circuit.X), circuit.Y)
+	circuit.Z = cs.Add(cs.Mul(circuit.X, circuit.Y), 5)
+	return nil
+}
+
+func init() {
+	var circuit, good, bad, public outputCircuit
+
+	good.X.Assign(3)
+	good.Y.Assign(9)
+	good.Z.Assign(32)
+
+	bad.X.Assign(3)
+	bad.Y.Assign(10)
+	bad.Z.Assign(35)
+
+	public.Y.Assign(9)
+	public.Z.Assign(32)
+
+	addEntry("output", &circuit, &good, &bad, &public)
+}
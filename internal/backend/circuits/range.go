@@ -10,7 +10,7 @@ type rangeCheckConstantCircuit struct {
 	Y frontend.Variable `gnark:",public"`
 }
 
-func (circuit *rangeCheckConstantCircuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *rangeCheckConstantCircuit) Define(curveID ecc.ID, cs frontend.API) error {
 	c1 := cs.Mul(circuit.X, circuit.Y)
 	c2 := cs.Mul(c1, circuit.Y)
 	c3 := cs.Add(circuit.X, circuit.Y)
@@ -38,7 +38,7 @@ type rangeCheckCircuit struct {
 	Y, Bound frontend.Variable `gnark:",public"`
 }
 
-func (circuit *rangeCheckCircuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *rangeCheckCircuit) Define(curveID ecc.ID, cs frontend.API) error {
 	c1 := cs.Mul(circuit.X, circuit.Y)
 	c2 := cs.Mul(c1, circuit.Y)
 	c3 := cs.Add(circuit.X, circuit.Y)
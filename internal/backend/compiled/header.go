@@ -0,0 +1,166 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiled
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+)
+
+// A serialized compiled constraint system is framed as
+//
+//	[magic | version | curveID | backendID | nbConstraints | nbInternal | nbSecret | nbPublic | payloadSize | checksum | payload]
+//
+// where magic is "gnrk", version, curveID and backendID are uint16, the counts and payloadSize are uint64,
+// and checksum is the CRC-32 (IEEE) of the payload. All the integers are big-endian.
+// The payload is the encoding of the constraint system, which depends on its type.
+const (
+	// FormatVersion is the version of the framed format written by WriteFrame
+	FormatVersion uint16 = 1
+
+	// HeaderSize is the size in bytes of an encoded Header
+	HeaderSize = 4 + 3*2 + 5*8 + 4
+)
+
+var magic = [4]byte{'g', 'n', 'r', 'k'}
+
+// ErrInvalidFormat is returned when reading a constraint system which is not properly framed
+var ErrInvalidFormat = errors.New("invalid constraint system format")
+
+// Header describes a serialized compiled constraint system
+type Header struct {
+	Version             uint16
+	CurveID             ecc.ID
+	BackendID           backend.ID
+	NbConstraints       uint64
+	NbInternalVariables uint64
+	NbSecretVariables   uint64
+	NbPublicVariables   uint64
+	PayloadSize         uint64
+	Checksum            uint32 // CRC-32 (IEEE) of the payload
+}
+
+// Check returns an error if the header doesn't describe a constraint system of the given curve and backend
+func (h *Header) Check(curveID ecc.ID, backendID backend.ID) error {
+	if h.CurveID != curveID {
+		return fmt.Errorf("constraint system is defined over %s, expected %s", CurveName(h.CurveID), CurveName(curveID))
+	}
+	if h.BackendID != backendID {
+		return fmt.Errorf("constraint system is compiled for %s, expected %s", h.BackendID, backendID)
+	}
+	return nil
+}
+
+// CheckCounts returns an error if the numbers of constraints and variables of the decoded constraint system
+// don't match the header
+func (h *Header) CheckCounts(nbConstraints, nbInternal, nbSecret, nbPublic int) error {
+	if h.NbConstraints != uint64(nbConstraints) || h.NbInternalVariables != uint64(nbInternal) ||
+		h.NbSecretVariables != uint64(nbSecret) || h.NbPublicVariables != uint64(nbPublic) {
+		return fmt.Errorf("%w: the numbers of constraints and variables don't match the header", ErrInvalidFormat)
+	}
+	return nil
+}
+
+// CurveName returns the name of the curve, or its numeric ID if it is unknown
+// (ecc.ID.String panics on unknown curves, which may be read from a corrupted header)
+func CurveName(curveID ecc.ID) string {
+	switch curveID {
+	case ecc.BN254, ecc.BLS12_377, ecc.BLS12_381, ecc.BW6_761:
+		return curveID.String()
+	default:
+		return fmt.Sprintf("unknown curve %d", uint16(curveID))
+	}
+}
+
+// ReadHeader reads and decodes a Header from r, and checks its magic number and version
+func ReadHeader(r io.Reader) (Header, error) {
+	var buf [HeaderSize]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return Header{}, fmt.Errorf("%w: truncated header", ErrInvalidFormat)
+		}
+		return Header{}, err
+	}
+	if !bytes.Equal(buf[:4], magic[:]) {
+		return Header{}, fmt.Errorf("%w: not a gnark constraint system", ErrInvalidFormat)
+	}
+
+	var h Header
+	h.Version = binary.BigEndian.Uint16(buf[4:])
+	if h.Version != FormatVersion {
+		return Header{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidFormat, h.Version)
+	}
+	h.CurveID = ecc.ID(binary.BigEndian.Uint16(buf[6:]))
+	h.BackendID = backend.ID(binary.BigEndian.Uint16(buf[8:]))
+	h.NbConstraints = binary.BigEndian.Uint64(buf[10:])
+	h.NbInternalVariables = binary.BigEndian.Uint64(buf[18:])
+	h.NbSecretVariables = binary.BigEndian.Uint64(buf[26:])
+	h.NbPublicVariables = binary.BigEndian.Uint64(buf[34:])
+	h.PayloadSize = binary.BigEndian.Uint64(buf[42:])
+	h.Checksum = binary.BigEndian.Uint32(buf[50:])
+	return h, nil
+}
+
+// WriteFrame writes the header, completed with the format version, the size and the checksum
+// of the payload, followed by the payload
+func WriteFrame(w io.Writer, h Header, payload []byte) (int64, error) {
+	var buf [HeaderSize]byte
+	copy(buf[:4], magic[:])
+	binary.BigEndian.PutUint16(buf[4:], FormatVersion)
+	binary.BigEndian.PutUint16(buf[6:], uint16(h.CurveID))
+	binary.BigEndian.PutUint16(buf[8:], uint16(h.BackendID))
+	binary.BigEndian.PutUint64(buf[10:], h.NbConstraints)
+	binary.BigEndian.PutUint64(buf[18:], h.NbInternalVariables)
+	binary.BigEndian.PutUint64(buf[26:], h.NbSecretVariables)
+	binary.BigEndian.PutUint64(buf[34:], h.NbPublicVariables)
+	binary.BigEndian.PutUint64(buf[42:], uint64(len(payload)))
+	binary.BigEndian.PutUint32(buf[50:], crc32.ChecksumIEEE(payload))
+
+	n, err := w.Write(buf[:])
+	if err != nil {
+		return int64(n), err
+	}
+	m, err := w.Write(payload)
+	return int64(n + m), err
+}
+
+// ReadFrame reads a header and the payload it describes from r, and checks the checksum of the payload
+func ReadFrame(r io.Reader) (Header, []byte, int64, error) {
+	h, err := ReadHeader(r)
+	if err != nil {
+		return h, nil, 0, err
+	}
+
+	// the payload is not allocated upfront, as the header may be corrupted
+	var payload bytes.Buffer
+	n, err := io.CopyN(&payload, r, int64(h.PayloadSize))
+	if err != nil {
+		if err == io.EOF {
+			return h, nil, HeaderSize + n, fmt.Errorf("%w: truncated payload", ErrInvalidFormat)
+		}
+		return h, nil, HeaderSize + n, err
+	}
+	if crc32.ChecksumIEEE(payload.Bytes()) != h.Checksum {
+		return h, nil, HeaderSize + n, fmt.Errorf("%w: checksum mismatch", ErrInvalidFormat)
+	}
+	return h, payload.Bytes(), HeaderSize + n, nil
+}
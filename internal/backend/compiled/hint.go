@@ -0,0 +1,50 @@
+// Copyright 2020 ConsenSys AG
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compiled
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark/backend/hint"
+)
+
+// Hint represents a wire which is not computed by a constraint, but by a hint function
+// evaluated by the solver on the values of Inputs (see package backend/hint)
+type Hint struct {
+	ID     hint.ID            // ID of the hint function (see hint.Lookup)
+	WireID int                // ID of the wire computed by the hint
+	Inputs []LinearExpression // inputs of the hint function
+}
+
+// PublicOutput represents a public wire computed by the solver (see gnark:",output"),
+// its value is the value of the wire ValueID, once the constraints are solved
+type PublicOutput struct {
+	WireID  int // ID of the public wire
+	ValueID int // ID of the wire holding the value of the output
+}
+
+// UnsatisfiedConstraint describes a constraint which doesn't hold, as reported by the solver in diagnostic mode
+//
+// The values are in regular form. For a R1C, L * R != O. For a SparseR1C, L + R + M + O + K != 0,
+// where L = qL.xa, R = qR.xb, M = qM.xa.xb, O = qO.xc and K = qK.
+type UnsatisfiedConstraint struct {
+	Assertion bool // true if the constraint is an assertion, false if it computes a wire
+	ID        int  // index of the constraint in Constraints (or in Assertions, for an assertion of a SparseR1CS)
+
+	L, R, M, O, K big.Int
+
+	DebugInfo string // debug info of the constraint, with the values of the wires
+	Wires     []int  // IDs of the wires of the constraint
+}
@@ -49,6 +49,9 @@ const (
 	Internal
 	Secret
 	Public
+	// Output is the visibility of the public outputs in a circuit struct (see parser.Visit).
+	// Public outputs are Public wires: this visibility is never encoded in a Term.
+	Output
 )
 
 // SolvingMethod is used by the R1CS solver
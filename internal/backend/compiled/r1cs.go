@@ -15,9 +15,14 @@
 package compiled
 
 import (
+	"fmt"
 	"io"
+	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/consensys/gnark/backend"
 )
 
 // R1CS decsribes a set of R1CS constraint
@@ -28,6 +33,8 @@ type R1CS struct {
 	NbInternalVariables int
 	NbPublicVariables   int // includes ONE wire
 	NbSecretVariables   int
+	PublicNames         []string // names of the public inputs, in the order of the witness (without the ONE wire)
+	SecretNames         []string // names of the secret inputs, in the order of the witness
 	Logs                []LogEntry
 	DebugInfo           []LogEntry
 
@@ -35,6 +42,19 @@ type R1CS struct {
 	NbConstraints   int // total number of constraints
 	NbCOConstraints int // number of constraints that need to be solved, the first of the Constraints slice
 	Constraints     []R1C
+
+	// Hints (wires computed outside of the constraints, see package backend/hint)
+	Hints []Hint
+
+	// Public outputs (public wires computed by the solver)
+	Outputs []PublicOutput
+
+	// Coefficients in the constraints, set when the R1CS is not tied to a curve
+	// (the R1CS of a curve hold their coefficients in the scalar field)
+	Coeffs []big.Int
+
+	// Minimum bit length of the scalar field modulus of the curve the R1CS may be tied to (see DivMod)
+	MinModulusBits int
 }
 
 // GetNbConstraints returns the number of constraints
@@ -50,9 +70,19 @@ func (r1cs *R1CS) GetNbVariables() (internal, secret, public int) {
 	return
 }
 
+// PublicInputNames returns the names of the public inputs (see parser.Visit), in the order of the public witness
+func (r1cs *R1CS) PublicInputNames() []string {
+	return r1cs.PublicNames
+}
+
+// SecretInputNames returns the names of the secret inputs (see parser.Visit), in the order of the witness
+func (r1cs *R1CS) SecretInputNames() []string {
+	return r1cs.SecretNames
+}
+
 // GetNbCoefficients return the number of unique coefficients needed in the R1CS
 func (r1cs *R1CS) GetNbCoefficients() int {
-	panic("not implemented")
+	return len(r1cs.Coeffs)
 }
 
 // CurveID returns ecc.UNKNOWN as this is a untyped R1CS using big.Int
@@ -65,14 +95,78 @@ func (r1cs *R1CS) FrSize() int {
 	panic("not implemented")
 }
 
-// WriteTo panics (can't serialize untyped R1CS)
-func (r1cs *R1CS) WriteTo(w io.Writer) (n int64, err error) {
-	panic("not implemented")
+// WriteTo encodes R1CS into provided io.Writer using cbor, framed by a Header which CurveID
+// is ecc.UNKNOWN (see WriteFrame)
+func (r1cs *R1CS) WriteTo(w io.Writer) (int64, error) {
+	// cbor doesn't encode the value of a big.Int, the coefficients are encoded separately
+	payload := r1csPayload{R1CS: *r1cs, Coeffs: encodeCoeffs(r1cs.Coeffs)}
+	payload.R1CS.Coeffs = nil
+	b, err := cbor.Marshal(&payload)
+	if err != nil {
+		return 0, err
+	}
+
+	header := Header{
+		CurveID:             ecc.UNKNOWN,
+		BackendID:           backend.GROTH16,
+		NbConstraints:       uint64(r1cs.NbConstraints),
+		NbInternalVariables: uint64(r1cs.NbInternalVariables),
+		NbSecretVariables:   uint64(r1cs.NbSecretVariables),
+		NbPublicVariables:   uint64(r1cs.NbPublicVariables),
+	}
+	return WriteFrame(w, header, b)
 }
 
-// ReadFrom panics (can't deserialize untyped R1CS)
-func (r1cs *R1CS) ReadFrom(r io.Reader) (n int64, err error) {
-	panic("not implemented")
+// ReadFrom attempts to decode R1CS from io.Reader (see WriteTo)
+func (r1cs *R1CS) ReadFrom(r io.Reader) (int64, error) {
+	header, b, n, err := ReadFrame(r)
+	if err != nil {
+		return n, err
+	}
+	if err := header.Check(ecc.UNKNOWN, backend.GROTH16); err != nil {
+		return n, err
+	}
+
+	var payload r1csPayload
+	if err := cbor.Unmarshal(b, &payload); err != nil {
+		return n, err
+	}
+	if err := header.CheckCounts(payload.R1CS.NbConstraints, payload.R1CS.NbInternalVariables, payload.R1CS.NbSecretVariables, payload.R1CS.NbPublicVariables); err != nil {
+		return n, err
+	}
+	coeffs, err := decodeCoeffs(payload.Coeffs)
+	if err != nil {
+		return n, err
+	}
+	*r1cs = payload.R1CS
+	r1cs.Coeffs = coeffs
+	return n, nil
+}
+
+// r1csPayload is the cbor payload of a serialized R1CS (see WriteTo)
+type r1csPayload struct {
+	R1CS   R1CS
+	Coeffs []string
+}
+
+// encodeCoeffs returns the coefficients in base 10
+func encodeCoeffs(coeffs []big.Int) []string {
+	res := make([]string, len(coeffs))
+	for i := 0; i < len(coeffs); i++ {
+		res[i] = coeffs[i].String()
+	}
+	return res
+}
+
+// decodeCoeffs returns the coefficients encoded by encodeCoeffs
+func decodeCoeffs(coeffs []string) ([]big.Int, error) {
+	res := make([]big.Int, len(coeffs))
+	for i := 0; i < len(coeffs); i++ {
+		if _, ok := res[i].SetString(coeffs[i], 10); !ok {
+			return nil, fmt.Errorf("%w: invalid coefficient %q", ErrInvalidFormat, coeffs[i])
+		}
+	}
+	return res, nil
 }
 
 // SetLoggerOutput replace existing logger output with provided one
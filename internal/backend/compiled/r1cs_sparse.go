@@ -19,6 +19,9 @@ import (
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/consensys/gnark/backend"
 )
 
 // SparseR1CS represents a Plonk like circuit
@@ -29,16 +32,32 @@ type SparseR1CS struct {
 	NbPublicVariables   int
 	NbSecretVariables   int
 
+	// Names of the inputs, in the order of the witness
+	PublicNames []string
+	SecretNames []string
+
 	// Constraints
 	Constraints []SparseR1C // list of PLONK constraints that yield an output (for example v3 == v1 * v2, return v3)
 	Assertions  []SparseR1C // list of PLONK constraints that yield no output (for example ensuring v1 == v2)
 
+	// Hints (wires computed outside of the constraints, see package backend/hint)
+	Hints []Hint
+
+	// Public outputs (public wires computed by the solver)
+	Outputs []PublicOutput
+
 	// Logs (e.g. variables that have been printed using cs.Println)
 	Logs []LogEntry
 
+	// DebugInfo of the assertions (one entry per assertion)
+	DebugInfo []LogEntry
+
 	// Coefficients in the constraints
 	Coeffs    []big.Int      // list of unique coefficients.
 	CoeffsIDs map[string]int // map to fast check existence of a coefficient (key = coeff.Text(16))
+
+	// Minimum bit length of the scalar field modulus of the curve the SparseR1CS may be tied to (see DivMod)
+	MinModulusBits int
 }
 
 // GetNbVariables return number of internal, secret and public variables
@@ -49,6 +68,16 @@ func (cs *SparseR1CS) GetNbVariables() (internal, secret, public int) {
 	return
 }
 
+// PublicInputNames returns the names of the public inputs (see parser.Visit), in the order of the public witness
+func (cs *SparseR1CS) PublicInputNames() []string {
+	return cs.PublicNames
+}
+
+// SecretInputNames returns the names of the secret inputs (see parser.Visit), in the order of the witness
+func (cs *SparseR1CS) SecretInputNames() []string {
+	return cs.SecretNames
+}
+
 // GetNbConstraints returns the number of constraints
 func (cs *SparseR1CS) GetNbConstraints() int {
 	return len(cs.Constraints)
@@ -75,14 +104,58 @@ func (cs *SparseR1CS) CurveID() ecc.ID {
 	return ecc.UNKNOWN
 }
 
-// WriteTo panics
-func (cs *SparseR1CS) WriteTo(w io.Writer) (n int64, err error) {
-	panic("not implemented")
+// WriteTo encodes SparseR1CS into provided io.Writer using cbor, framed by a Header which CurveID
+// is ecc.UNKNOWN (see WriteFrame)
+func (cs *SparseR1CS) WriteTo(w io.Writer) (int64, error) {
+	// cbor doesn't encode the value of a big.Int, the coefficients are encoded separately
+	payload := sparseR1CSPayload{SparseR1CS: *cs, Coeffs: encodeCoeffs(cs.Coeffs)}
+	payload.SparseR1CS.Coeffs = nil
+	b, err := cbor.Marshal(&payload)
+	if err != nil {
+		return 0, err
+	}
+
+	header := Header{
+		CurveID:             ecc.UNKNOWN,
+		BackendID:           backend.PLONK,
+		NbConstraints:       uint64(len(cs.Constraints)),
+		NbInternalVariables: uint64(cs.NbInternalVariables),
+		NbSecretVariables:   uint64(cs.NbSecretVariables),
+		NbPublicVariables:   uint64(cs.NbPublicVariables),
+	}
+	return WriteFrame(w, header, b)
 }
 
-// ReadFrom panics
-func (cs *SparseR1CS) ReadFrom(r io.Reader) (n int64, err error) {
-	panic("not implemented")
+// ReadFrom attempts to decode SparseR1CS from io.Reader (see WriteTo)
+func (cs *SparseR1CS) ReadFrom(r io.Reader) (int64, error) {
+	header, b, n, err := ReadFrame(r)
+	if err != nil {
+		return n, err
+	}
+	if err := header.Check(ecc.UNKNOWN, backend.PLONK); err != nil {
+		return n, err
+	}
+
+	var payload sparseR1CSPayload
+	if err := cbor.Unmarshal(b, &payload); err != nil {
+		return n, err
+	}
+	if err := header.CheckCounts(len(payload.SparseR1CS.Constraints), payload.SparseR1CS.NbInternalVariables, payload.SparseR1CS.NbSecretVariables, payload.SparseR1CS.NbPublicVariables); err != nil {
+		return n, err
+	}
+	coeffs, err := decodeCoeffs(payload.Coeffs)
+	if err != nil {
+		return n, err
+	}
+	*cs = payload.SparseR1CS
+	cs.Coeffs = coeffs
+	return n, nil
+}
+
+// sparseR1CSPayload is the cbor payload of a serialized SparseR1CS (see WriteTo)
+type sparseR1CSPayload struct {
+	SparseR1CS SparseR1CS
+	Coeffs     []string
 }
 
 // SetLoggerOutput replace existing logger output with provided one
@@ -25,12 +25,24 @@ import (
 //			Z frontend.Variable `gnark:"-"`
 // 		}
 // it is then the developer responsability to do circuit.Z = circuit.Y in the Define() method
+//
+// using "output" marks the variable as a public output: it is a public variable whose value is computed
+// by the circuit. It must be assigned in the Define() method, and is visited with the compiled.Output visibility
+// 		type MyCircuit struct {
+// 			X frontend.Variable
+// 			Y frontend.Variable `gnark:",output"`
+// 		}
+// 		func (circuit *MyCircuit) Define(curveID ecc.ID, cs frontend.API) error {
+// 			circuit.Y = cs.Mul(circuit.X, circuit.X)
+// 			return nil
+// 		}
 type Tag string
 
 const (
 	tagKey    Tag = "gnark"
 	optPublic Tag = "public"
 	optSecret Tag = "secret"
+	optOutput Tag = "output"
 	optEmbed  Tag = "embed"
 	optOmit   Tag = "-"
 )
@@ -88,6 +100,8 @@ func Visit(input interface{}, baseName string, parentVisibility compiled.Visibil
 						visibility = compiled.Secret
 					} else if opts.Contains(string(optPublic)) {
 						visibility = compiled.Public
+					} else if opts.Contains(string(optOutput)) {
+						visibility = compiled.Output
 					} else if opts.Contains(string(optEmbed)) {
 						name = ""
 						visibility = compiled.Unset
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	frbls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	frbls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	frbn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	frbw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+)
+
+// FrModulus returns the modulus of the scalar field of the curve, or nil if the curve is unknown
+func FrModulus(curveID ecc.ID) *big.Int {
+	switch curveID {
+	case ecc.BN254:
+		return frbn254.Modulus()
+	case ecc.BLS12_381:
+		return frbls12381.Modulus()
+	case ecc.BLS12_377:
+		return frbls12377.Modulus()
+	case ecc.BW6_761:
+		return frbw6761.Modulus()
+	default:
+		return nil
+	}
+}
@@ -58,7 +58,7 @@ type E12 struct {
 }
 
 // GetBLS377ExtensionFp12 get extension field parameters for bls12377
-func GetBLS377ExtensionFp12(cs *frontend.ConstraintSystem) Extension {
+func GetBLS377ExtensionFp12(cs frontend.API) Extension {
 
 	res := Extension{}
 
@@ -94,7 +94,7 @@ func GetBLS377ExtensionFp12(cs *frontend.ConstraintSystem) Extension {
 }
 
 // SetOne returns a newly allocated element equal to 1
-func (e *E12) SetOne(cs *frontend.ConstraintSystem) *E12 {
+func (e *E12) SetOne(cs frontend.API) *E12 {
 	e.C0.B0.A0 = cs.Constant(1)
 	e.C0.B0.A1 = cs.Constant(0)
 	e.C0.B1.A0 = cs.Constant(0)
@@ -111,28 +111,28 @@ func (e *E12) SetOne(cs *frontend.ConstraintSystem) *E12 {
 }
 
 // Add adds 2 elmts in Fp12
-func (e *E12) Add(cs *frontend.ConstraintSystem, e1, e2 *E12) *E12 {
+func (e *E12) Add(cs frontend.API, e1, e2 *E12) *E12 {
 	e.C0.Add(cs, &e1.C0, &e2.C0)
 	e.C1.Add(cs, &e1.C1, &e2.C1)
 	return e
 }
 
 // Sub substracts 2 elmts in Fp12
-func (e *E12) Sub(cs *frontend.ConstraintSystem, e1, e2 *E12) *E12 {
+func (e *E12) Sub(cs frontend.API, e1, e2 *E12) *E12 {
 	e.C0.Sub(cs, &e1.C0, &e2.C0)
 	e.C1.Sub(cs, &e1.C1, &e2.C1)
 	return e
 }
 
 // Neg negates an Fp6elmt
-func (e *E12) Neg(cs *frontend.ConstraintSystem, e1 *E12) *E12 {
+func (e *E12) Neg(cs frontend.API, e1 *E12) *E12 {
 	e.C0.Neg(cs, &e1.C0)
 	e.C1.Neg(cs, &e1.C1)
 	return e
 }
 
 // Mul multiplies 2 elmts in Fp12
-func (e *E12) Mul(cs *frontend.ConstraintSystem, e1, e2 *E12, ext Extension) *E12 {
+func (e *E12) Mul(cs frontend.API, e1, e2 *E12, ext Extension) *E12 {
 
 	var u, v, ac, bd E6
 	u.Add(cs, &e1.C0, &e1.C1) // 6C
@@ -150,7 +150,7 @@ func (e *E12) Mul(cs *frontend.ConstraintSystem, e1, e2 *E12, ext Extension) *E1
 }
 
 // Conjugate applies Frob**6 (conjugation over Fp6)
-func (e *E12) Conjugate(cs *frontend.ConstraintSystem, e1 *E12) *E12 {
+func (e *E12) Conjugate(cs frontend.API, e1 *E12) *E12 {
 	zero := NewFp6Zero(cs)
 	e.C1.Sub(cs, &zero, &e1.C1)
 	e.C0 = e1.C0
@@ -158,7 +158,7 @@ func (e *E12) Conjugate(cs *frontend.ConstraintSystem, e1 *E12) *E12 {
 }
 
 // MulBy034 multiplication by sparse element
-func (e *E12) MulBy034(cs *frontend.ConstraintSystem, c0, c3, c4 *E2, ext Extension) *E12 {
+func (e *E12) MulBy034(cs frontend.API, c0, c3, c4 *E2, ext Extension) *E12 {
 
 	var z0, z1, z2, z3, z4, z5, tmp1, tmp2 E2
 	var t [12]E2
@@ -209,7 +209,7 @@ func (e *E12) MulBy034(cs *frontend.ConstraintSystem, c0, c3, c4 *E2, ext Extens
 }
 
 // MulByVW multiplies an e12 elmt by an elmt of the form a*VW (Fp6=Fp2(V), Fp12 = Fp6(W))
-func (e *E12) MulByVW(cs *frontend.ConstraintSystem, e1 *E12, e2 *E2, ext Extension) *E12 {
+func (e *E12) MulByVW(cs frontend.API, e1 *E12, e2 *E2, ext Extension) *E12 {
 
 	tmp := E2{}
 	tmp.MulByIm(cs, e2, ext)
@@ -230,7 +230,7 @@ func (e *E12) MulByVW(cs *frontend.ConstraintSystem, e1 *E12, e2 *E2, ext Extens
 }
 
 // MulByV multiplies an e12 elmt by an elmt of the form a*V (Fp6=Fp2(V), Fp12 = Fp6(W))
-func (e *E12) MulByV(cs *frontend.ConstraintSystem, e1 *E12, e2 *E2, ext Extension) *E12 {
+func (e *E12) MulByV(cs frontend.API, e1 *E12, e2 *E2, ext Extension) *E12 {
 
 	tmp := E2{}
 	tmp.MulByIm(cs, e2, ext)
@@ -251,7 +251,7 @@ func (e *E12) MulByV(cs *frontend.ConstraintSystem, e1 *E12, e2 *E2, ext Extensi
 }
 
 // MulByV2W multiplies an e12 elmt by an elmt of the form a*V**2W (Fp6=Fp2(V), Fp12 = Fp6(W))
-func (e *E12) MulByV2W(cs *frontend.ConstraintSystem, e1 *E12, e2 *E2, ext Extension) *E12 {
+func (e *E12) MulByV2W(cs frontend.API, e1 *E12, e2 *E2, ext Extension) *E12 {
 
 	tmp := E2{}
 	tmp.MulByIm(cs, e2, ext)
@@ -272,7 +272,7 @@ func (e *E12) MulByV2W(cs *frontend.ConstraintSystem, e1 *E12, e2 *E2, ext Exten
 }
 
 // Frobenius applies frob to an fp12 elmt
-func (e *E12) Frobenius(cs *frontend.ConstraintSystem, e1 *E12, ext Extension) *E12 {
+func (e *E12) Frobenius(cs frontend.API, e1 *E12, ext Extension) *E12 {
 
 	e.C0.B0.Conjugate(cs, &e1.C0.B0)
 	e.C0.B1.Conjugate(cs, &e1.C0.B1).MulByFp(cs, &e.C0.B1, ext.frobv)
@@ -286,7 +286,7 @@ func (e *E12) Frobenius(cs *frontend.ConstraintSystem, e1 *E12, ext Extension) *
 }
 
 // FrobeniusSquare applies frob**2 to an fp12 elmt
-func (e *E12) FrobeniusSquare(cs *frontend.ConstraintSystem, e1 *E12, ext Extension) *E12 {
+func (e *E12) FrobeniusSquare(cs frontend.API, e1 *E12, ext Extension) *E12 {
 
 	e.C0.B0 = e1.C0.B0
 	e.C0.B1.MulByFp(cs, &e1.C0.B1, ext.frob2v)
@@ -299,7 +299,7 @@ func (e *E12) FrobeniusSquare(cs *frontend.ConstraintSystem, e1 *E12, ext Extens
 }
 
 // FrobeniusCube applies frob**2 to an fp12 elmt
-func (e *E12) FrobeniusCube(cs *frontend.ConstraintSystem, e1 *E12, ext Extension) *E12 {
+func (e *E12) FrobeniusCube(cs frontend.API, e1 *E12, ext Extension) *E12 {
 
 	e.C0.B0.Conjugate(cs, &e1.C0.B0)
 	e.C0.B1.Conjugate(cs, &e1.C0.B1).MulByFp(cs, &e.C0.B1, ext.frob3v)
@@ -312,7 +312,7 @@ func (e *E12) FrobeniusCube(cs *frontend.ConstraintSystem, e1 *E12, ext Extensio
 }
 
 // Inverse inverse an elmt in Fp12
-func (e *E12) Inverse(cs *frontend.ConstraintSystem, e1 *E12, ext Extension) *E12 {
+func (e *E12) Inverse(cs frontend.API, e1 *E12, ext Extension) *E12 {
 
 	var t [2]E6
 	var buf E6
@@ -331,14 +331,14 @@ func (e *E12) Inverse(cs *frontend.ConstraintSystem, e1 *E12, ext Extension) *E1
 }
 
 // ConjugateFp12 conjugates an Fp12 elmt (applies Frob**6)
-func (e *E12) ConjugateFp12(cs *frontend.ConstraintSystem, e1 *E12) *E12 {
+func (e *E12) ConjugateFp12(cs frontend.API, e1 *E12) *E12 {
 	e.C0 = e1.C0
 	e.C1.Neg(cs, &e1.C1)
 	return e
 }
 
 // Select sets e to r1 if b=1, r2 otherwise
-func (e *E12) Select(cs *frontend.ConstraintSystem, b frontend.Variable, r1, r2 *E12) *E12 {
+func (e *E12) Select(cs frontend.API, b frontend.Variable, r1, r2 *E12) *E12 {
 
 	e.C0.B0.A0 = cs.Select(b, r1.C0.B0.A0, r2.C0.B0.A0)
 	e.C0.B0.A1 = cs.Select(b, r1.C0.B0.A1, r2.C0.B0.A1)
@@ -359,7 +359,7 @@ func (e *E12) Select(cs *frontend.ConstraintSystem, b frontend.Variable, r1, r2
 // FixedExponentiation compute e1**exponent, where the exponent is hardcoded
 // This function is only used for the final expo of the pairing for bls12377, so the exponent is supposed to be hardcoded
 // and on 64 bits.
-func (e *E12) FixedExponentiation(cs *frontend.ConstraintSystem, e1 *E12, exponent uint64, ext Extension) *E12 {
+func (e *E12) FixedExponentiation(cs frontend.API, e1 *E12, exponent uint64, ext Extension) *E12 {
 
 	var expoBin [64]uint8
 	for i := 0; i < 64; i++ {
@@ -381,7 +381,7 @@ func (e *E12) FixedExponentiation(cs *frontend.ConstraintSystem, e1 *E12, expone
 }
 
 // FinalExponentiation computes the final expo x**(p**6-1)(p**2+1)(p**4 - p**2 +1)/r
-func (e *E12) FinalExponentiation(cs *frontend.ConstraintSystem, e1 *E12, genT uint64, ext Extension) *E12 {
+func (e *E12) FinalExponentiation(cs frontend.API, e1 *E12, genT uint64, ext Extension) *E12 {
 
 	result := *e1
 
@@ -429,7 +429,7 @@ func (e *E12) Assign(a *bls12377.E12) {
 }
 
 // MustBeEqual constraint self to be equal to other into the given constraint system
-func (e *E12) MustBeEqual(cs *frontend.ConstraintSystem, other E12) {
+func (e *E12) MustBeEqual(cs frontend.API, other E12) {
 	e.C0.MustBeEqual(cs, other.C0)
 	e.C1.MustBeEqual(cs, other.C1)
 }
@@ -35,7 +35,7 @@ type fp12Add struct {
 	C    E12 `gnark:",public"`
 }
 
-func (circuit *fp12Add) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12Add) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	expected.Add(cs, &circuit.A, &circuit.B)
 	expected.MustBeEqual(cs, circuit.C)
@@ -70,7 +70,7 @@ type fp12Sub struct {
 	C    E12 `gnark:",public"`
 }
 
-func (circuit *fp12Sub) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12Sub) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	expected.Sub(cs, &circuit.A, &circuit.B)
 	expected.MustBeEqual(cs, circuit.C)
@@ -105,7 +105,7 @@ type fp12Mul struct {
 	C    E12 `gnark:",public"`
 }
 
-func (circuit *fp12Mul) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12Mul) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	ext := GetBLS377ExtensionFp12(cs)
 	expected.Mul(cs, &circuit.A, &circuit.B, ext)
@@ -141,7 +141,7 @@ type fp12Conjugate struct {
 	C E12 `gnark:",public"`
 }
 
-func (circuit *fp12Conjugate) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12Conjugate) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	expected.Conjugate(cs, &circuit.A)
 	expected.MustBeEqual(cs, circuit.C)
@@ -175,7 +175,7 @@ type fp12MulByV struct {
 	C E12 `gnark:",public"`
 }
 
-func (circuit *fp12MulByV) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12MulByV) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	ext := GetBLS377ExtensionFp12(cs)
 	expected.MulByV(cs, &circuit.A, &circuit.B, ext)
@@ -215,7 +215,7 @@ type fp12MulByV2W struct {
 	C E12 `gnark:",public"`
 }
 
-func (circuit *fp12MulByV2W) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12MulByV2W) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	ext := GetBLS377ExtensionFp12(cs)
 	expected.MulByV2W(cs, &circuit.A, &circuit.B, ext)
@@ -255,7 +255,7 @@ type fp12MulByVW struct {
 	C E12 `gnark:",public"`
 }
 
-func (circuit *fp12MulByVW) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12MulByVW) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	ext := GetBLS377ExtensionFp12(cs)
 	expected.MulByVW(cs, &circuit.A, &circuit.B, ext)
@@ -294,7 +294,7 @@ type fp12Frobenius struct {
 	C, D, E E12 `gnark:",public"`
 }
 
-func (circuit *fp12Frobenius) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12Frobenius) Define(curveID ecc.ID, cs frontend.API) error {
 	ext := GetBLS377ExtensionFp12(cs)
 	fb := E12{}
 	fb.Frobenius(cs, &circuit.A, ext)
@@ -340,7 +340,7 @@ type fp12Inverse struct {
 	C E12 `gnark:",public"`
 }
 
-func (circuit *fp12Inverse) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12Inverse) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	ext := GetBLS377ExtensionFp12(cs)
 	expected.Inverse(cs, &circuit.A, ext)
@@ -374,7 +374,7 @@ type fp12FixedExpo struct {
 	C E12 `gnark:",public"`
 }
 
-func (circuit *fp12FixedExpo) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12FixedExpo) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	ext := GetBLS377ExtensionFp12(cs)
 	expo := uint64(9586122913090633729)
@@ -410,7 +410,7 @@ type fp12FinalExpo struct {
 	C E12 `gnark:",public"`
 }
 
-func (circuit *fp12FinalExpo) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12FinalExpo) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E12{}
 	ext := GetBLS377ExtensionFp12(cs)
 	expo := uint64(9586122913090633729)
@@ -446,7 +446,7 @@ type fp12MulBy034 struct {
 	B, C, D E2
 }
 
-func (circuit *fp12MulBy034) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp12MulBy034) Define(curveID ecc.ID, cs frontend.API) error {
 	ext := GetBLS377ExtensionFp12(cs)
 	circuit.A.MulBy034(cs, &circuit.B, &circuit.C, &circuit.D, ext)
 	circuit.A.MustBeEqual(cs, circuit.W)
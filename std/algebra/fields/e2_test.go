@@ -29,10 +29,10 @@ import (
 
 type e2TestCircuit struct {
 	A, B, C E2
-	define  func(curveID ecc.ID, cs *frontend.ConstraintSystem, A, B, C E2) error
+	define  func(curveID ecc.ID, cs frontend.API, A, B, C E2) error
 }
 
-func (circuit *e2TestCircuit) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *e2TestCircuit) Define(curveID ecc.ID, cs frontend.API) error {
 	return circuit.define(curveID, cs, circuit.A, circuit.B, circuit.C)
 }
 
@@ -40,7 +40,7 @@ func TestAddFp2(t *testing.T) {
 
 	// test circuit
 	circuit := e2TestCircuit{
-		define: func(curveID ecc.ID, cs *frontend.ConstraintSystem, A, B, C E2) error {
+		define: func(curveID ecc.ID, cs frontend.API, A, B, C E2) error {
 			expected := E2{}
 			expected.Add(cs, &A, &B)
 			expected.MustBeEqual(cs, C)
@@ -74,7 +74,7 @@ func TestSubFp2(t *testing.T) {
 
 	// test circuit
 	circuit := e2TestCircuit{
-		define: func(curveID ecc.ID, cs *frontend.ConstraintSystem, A, B, C E2) error {
+		define: func(curveID ecc.ID, cs frontend.API, A, B, C E2) error {
 			expected := E2{}
 			expected.Sub(cs, &A, &B)
 			expected.MustBeEqual(cs, C)
@@ -107,7 +107,7 @@ func TestSubFp2(t *testing.T) {
 func TestMulFp2(t *testing.T) {
 	// test circuit
 	circuit := e2TestCircuit{
-		define: func(curveID ecc.ID, cs *frontend.ConstraintSystem, A, B, C E2) error {
+		define: func(curveID ecc.ID, cs frontend.API, A, B, C E2) error {
 			ext := Extension{uSquare: -5}
 			expected := E2{}
 			expected.Mul(cs, &A, &B, ext)
@@ -143,7 +143,7 @@ type fp2MulByFp struct {
 	C E2 `gnark:",public"`
 }
 
-func (circuit *fp2MulByFp) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp2MulByFp) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E2{}
 	expected.MulByFp(cs, &circuit.A, circuit.B)
 
@@ -181,7 +181,7 @@ type fp2Conjugate struct {
 	C E2 `gnark:",public"`
 }
 
-func (circuit *fp2Conjugate) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp2Conjugate) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E2{}
 	expected.Conjugate(cs, &circuit.A)
 
@@ -215,7 +215,7 @@ type fp2Inverse struct {
 	C E2 `gnark:",public"`
 }
 
-func (circuit *fp2Inverse) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp2Inverse) Define(curveID ecc.ID, cs frontend.API) error {
 	ext := Extension{uSquare: -5}
 	expected := E2{}
 	expected.Inverse(cs, &circuit.A, ext)
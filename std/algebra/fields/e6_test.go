@@ -26,7 +26,7 @@ import (
 	"github.com/consensys/gnark/frontend"
 )
 
-func getBLS377ExtensionFp6(cs *frontend.ConstraintSystem) Extension {
+func getBLS377ExtensionFp6(cs frontend.API) Extension {
 	res := Extension{}
 	res.uSquare = -5
 	res.vCube = &E2{A0: cs.Constant(0), A1: cs.Constant(1)}
@@ -41,7 +41,7 @@ type fp6Add struct {
 	C    E6 `gnark:",public"`
 }
 
-func (circuit *fp6Add) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp6Add) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E6{}
 	expected.Add(cs, &circuit.A, &circuit.B)
 	expected.MustBeEqual(cs, circuit.C)
@@ -76,7 +76,7 @@ type fp6Sub struct {
 	C    E6 `gnark:",public"`
 }
 
-func (circuit *fp6Sub) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp6Sub) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E6{}
 	expected.Sub(cs, &circuit.A, &circuit.B)
 	expected.MustBeEqual(cs, circuit.C)
@@ -111,7 +111,7 @@ type fp6Mul struct {
 	C    E6 `gnark:",public"`
 }
 
-func (circuit *fp6Mul) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp6Mul) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E6{}
 	ext := getBLS377ExtensionFp6(cs)
 	expected.Mul(cs, &circuit.A, &circuit.B, ext)
@@ -147,7 +147,7 @@ type fp6MulByNonResidue struct {
 	C E6 `gnark:",public"`
 }
 
-func (circuit *fp6MulByNonResidue) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp6MulByNonResidue) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E6{}
 	ext := getBLS377ExtensionFp6(cs)
 	expected.MulByNonResidue(cs, &circuit.A, ext)
@@ -183,7 +183,7 @@ type fp6Inverse struct {
 	C E6 `gnark:",public"`
 }
 
-func (circuit *fp6Inverse) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *fp6Inverse) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := E6{}
 	ext := getBLS377ExtensionFp6(cs)
 	expected.Inverse(cs, &circuit.A, ext)
@@ -37,7 +37,7 @@ type g1AddAssign struct {
 	C    G1Jac `gnark:",public"`
 }
 
-func (circuit *g1AddAssign) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g1AddAssign) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.AddAssign(cs, &circuit.B)
 	expected.MustBeEqual(cs, circuit.C)
@@ -78,7 +78,7 @@ type g1AddAssignAffine struct {
 	C    G1Affine `gnark:",public"`
 }
 
-func (circuit *g1AddAssignAffine) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g1AddAssignAffine) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.AddAssign(cs, &circuit.B)
 	expected.MustBeEqual(cs, circuit.C)
@@ -123,7 +123,7 @@ type g1DoubleAssign struct {
 	C G1Jac `gnark:",public"`
 }
 
-func (circuit *g1DoubleAssign) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g1DoubleAssign) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.DoubleAssign(cs)
 	expected.MustBeEqual(cs, circuit.C)
@@ -162,7 +162,7 @@ type g1DoubleAffine struct {
 	C G1Affine `gnark:",public"`
 }
 
-func (circuit *g1DoubleAffine) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g1DoubleAffine) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.Double(cs, &circuit.A)
 	expected.MustBeEqual(cs, circuit.C)
@@ -204,7 +204,7 @@ type g1Neg struct {
 	C G1Jac `gnark:",public"`
 }
 
-func (circuit *g1Neg) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g1Neg) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := G1Jac{}
 	expected.Neg(cs, &circuit.A)
 	expected.MustBeEqual(cs, circuit.C)
@@ -244,7 +244,7 @@ type g1ScalarMul struct {
 	r fr.Element
 }
 
-func (circuit *g1ScalarMul) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g1ScalarMul) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := G1Affine{}
 	expected.ScalarMul(cs, &circuit.A, circuit.r.String(), 256)
 	expected.MustBeEqual(cs, circuit.C)
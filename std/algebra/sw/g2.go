@@ -37,7 +37,7 @@ type G2Affine struct {
 }
 
 // ToProj sets p to p1 in projective coords and return it
-func (p *G2Jac) ToProj(cs *frontend.ConstraintSystem, p1 *G2Jac, ext fields.Extension) *G2Jac {
+func (p *G2Jac) ToProj(cs frontend.API, p1 *G2Jac, ext fields.Extension) *G2Jac {
 	p.X.Mul(cs, &p1.X, &p1.Z, ext)
 	p.Y = p1.Y
 	var t fields.E2
@@ -47,7 +47,7 @@ func (p *G2Jac) ToProj(cs *frontend.ConstraintSystem, p1 *G2Jac, ext fields.Exte
 }
 
 // Neg outputs -p
-func (p *G2Jac) Neg(cs *frontend.ConstraintSystem, p1 *G2Jac) *G2Jac {
+func (p *G2Jac) Neg(cs frontend.API, p1 *G2Jac) *G2Jac {
 	p.Y.Neg(cs, &p1.Y)
 	p.X = p1.X
 	p.Z = p1.Z
@@ -55,7 +55,7 @@ func (p *G2Jac) Neg(cs *frontend.ConstraintSystem, p1 *G2Jac) *G2Jac {
 }
 
 // Neg outputs -p
-func (p *G2Affine) Neg(cs *frontend.ConstraintSystem, p1 *G2Affine) *G2Affine {
+func (p *G2Affine) Neg(cs frontend.API, p1 *G2Affine) *G2Affine {
 	p.Y.Neg(cs, &p1.Y)
 	p.X = p1.X
 	return p
@@ -63,7 +63,7 @@ func (p *G2Affine) Neg(cs *frontend.ConstraintSystem, p1 *G2Affine) *G2Affine {
 
 // AddAssign adds 2 point in Jacobian coordinates
 // p=p, a=p1
-func (p *G2Jac) AddAssign(cs *frontend.ConstraintSystem, p1 *G2Jac, ext fields.Extension) *G2Jac {
+func (p *G2Jac) AddAssign(cs frontend.API, p1 *G2Jac, ext fields.Extension) *G2Jac {
 
 	var Z1Z1, Z2Z2, U1, U2, S1, S2, H, I, J, r, V fields.E2
 
@@ -116,7 +116,7 @@ func (p *G2Jac) AddAssign(cs *frontend.ConstraintSystem, p1 *G2Jac, ext fields.E
 }
 
 // AddAssign add p1 to p and return p
-func (p *G2Affine) AddAssign(cs *frontend.ConstraintSystem, p1 *G2Affine, ext fields.Extension) *G2Affine {
+func (p *G2Affine) AddAssign(cs frontend.API, p1 *G2Affine, ext fields.Extension) *G2Affine {
 
 	var n, d, l, xr, yr fields.E2
 
@@ -142,7 +142,7 @@ func (p *G2Affine) AddAssign(cs *frontend.ConstraintSystem, p1 *G2Affine, ext fi
 
 // Double compute 2*p1, assign the result to p and return it
 // Only for curve with j invariant 0 (a=0).
-func (p *G2Affine) Double(cs *frontend.ConstraintSystem, p1 *G2Affine, ext fields.Extension) *G2Affine {
+func (p *G2Affine) Double(cs frontend.API, p1 *G2Affine, ext fields.Extension) *G2Affine {
 
 	var n, d, l, xr, yr fields.E2
 
@@ -169,7 +169,7 @@ func (p *G2Affine) Double(cs *frontend.ConstraintSystem, p1 *G2Affine, ext field
 }
 
 // Double doubles a point in jacobian coords
-func (p *G2Jac) Double(cs *frontend.ConstraintSystem, p1 *G2Jac, ext fields.Extension) *G2Jac {
+func (p *G2Jac) Double(cs frontend.API, p1 *G2Jac, ext fields.Extension) *G2Jac {
 
 	var XX, YY, YYYY, ZZ, S, M, T fields.E2
 
@@ -206,7 +206,7 @@ func (p *G2Jac) Assign(p1 *bls12377.G2Jac) {
 }
 
 // MustBeEqual constraint self to be equal to other into the given constraint system
-func (p *G2Jac) MustBeEqual(cs *frontend.ConstraintSystem, other G2Jac) {
+func (p *G2Jac) MustBeEqual(cs frontend.API, other G2Jac) {
 	p.X.MustBeEqual(cs, other.X)
 	p.Y.MustBeEqual(cs, other.Y)
 	p.Z.MustBeEqual(cs, other.Z)
@@ -219,7 +219,7 @@ func (p *G2Affine) Assign(p1 *bls12377.G2Affine) {
 }
 
 // MustBeEqual constraint self to be equal to other into the given constraint system
-func (p *G2Affine) MustBeEqual(cs *frontend.ConstraintSystem, other G2Affine) {
+func (p *G2Affine) MustBeEqual(cs frontend.API, other G2Affine) {
 	p.X.MustBeEqual(cs, other.X)
 	p.Y.MustBeEqual(cs, other.Y)
 }
@@ -38,7 +38,7 @@ type g2AddAssign struct {
 	C    G2Jac `gnark:",public"`
 }
 
-func (circuit *g2AddAssign) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g2AddAssign) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.AddAssign(cs, &circuit.B, fields.GetBLS377ExtensionFp12(cs))
 	expected.MustBeEqual(cs, circuit.C)
@@ -79,7 +79,7 @@ type g2AddAssignAffine struct {
 	C    G2Affine `gnark:",public"`
 }
 
-func (circuit *g2AddAssignAffine) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g2AddAssignAffine) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.AddAssign(cs, &circuit.B, fields.GetBLS377ExtensionFp12(cs))
 	expected.MustBeEqual(cs, circuit.C)
@@ -124,7 +124,7 @@ type g2DoubleAssign struct {
 	C G2Jac `gnark:",public"`
 }
 
-func (circuit *g2DoubleAssign) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g2DoubleAssign) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.Double(cs, &circuit.A, fields.GetBLS377ExtensionFp12(cs))
 	expected.MustBeEqual(cs, circuit.C)
@@ -163,7 +163,7 @@ type g2DoubleAffine struct {
 	C G2Affine `gnark:",public"`
 }
 
-func (circuit *g2DoubleAffine) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g2DoubleAffine) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := circuit.A
 	expected.Double(cs, &circuit.A, fields.GetBLS377ExtensionFp12(cs))
 	expected.MustBeEqual(cs, circuit.C)
@@ -205,7 +205,7 @@ type g2Neg struct {
 	C G2Jac `gnark:",public"`
 }
 
-func (circuit *g2Neg) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *g2Neg) Define(curveID ecc.ID, cs frontend.API) error {
 	expected := G2Jac{}
 	expected.Neg(cs, &circuit.A)
 	expected.MustBeEqual(cs, circuit.C)
@@ -30,7 +30,7 @@ type Point struct {
 
 // MustBeOnCurve checks if a point is on the twisted Edwards curve
 // ax^2 + y^2 = 1 + d*x^2*y^2
-func (p *Point) MustBeOnCurve(cs *frontend.ConstraintSystem, curve EdCurve) {
+func (p *Point) MustBeOnCurve(cs frontend.API, curve EdCurve) {
 
 	one := big.NewInt(1)
 
@@ -50,7 +50,7 @@ func (p *Point) MustBeOnCurve(cs *frontend.ConstraintSystem, curve EdCurve) {
 
 // AddFixedPoint Adds two points, among which is one fixed point (the base), on a twisted edwards curve (eg jubjub)
 // p1, base, ecurve are respectively: the point to add, a known base point, and the parameters of the twisted edwards curve
-func (p *Point) AddFixedPoint(cs *frontend.ConstraintSystem, p1 *Point /*basex*/, x /*basey*/, y interface{}, curve EdCurve) *Point {
+func (p *Point) AddFixedPoint(cs frontend.API, p1 *Point /*basex*/, x /*basey*/, y interface{}, curve EdCurve) *Point {
 
 	// https://eprint.iacr.org/2008/013.pdf
 
@@ -74,7 +74,7 @@ func (p *Point) AddFixedPoint(cs *frontend.ConstraintSystem, p1 *Point /*basex*/
 
 // AddGeneric Adds two points on a twisted edwards curve (eg jubjub)
 // p1, p2, c are respectively: the point to add, a known base point, and the parameters of the twisted edwards curve
-func (p *Point) AddGeneric(cs *frontend.ConstraintSystem, p1, p2 *Point, curve EdCurve) *Point {
+func (p *Point) AddGeneric(cs frontend.API, p1, p2 *Point, curve EdCurve) *Point {
 
 	// https://eprint.iacr.org/2008/013.pdf
 
@@ -98,7 +98,7 @@ func (p *Point) AddGeneric(cs *frontend.ConstraintSystem, p1, p2 *Point, curve E
 }
 
 // Double doubles a points in SNARK coordinates
-func (p *Point) Double(cs *frontend.ConstraintSystem, p1 *Point, curve EdCurve) *Point {
+func (p *Point) Double(cs frontend.API, p1 *Point, curve EdCurve) *Point {
 	p.AddGeneric(cs, p1, p1, curve)
 	return p
 }
@@ -108,7 +108,7 @@ func (p *Point) Double(cs *frontend.ConstraintSystem, p1 *Point, curve EdCurve)
 // curve: parameters of the Edwards curve
 // scal: scalar as a SNARK constraint
 // Standard left to right double and add
-func (p *Point) ScalarMulNonFixedBase(cs *frontend.ConstraintSystem, p1 *Point, scalar frontend.Variable, curve EdCurve) *Point {
+func (p *Point) ScalarMulNonFixedBase(cs frontend.API, p1 *Point, scalar frontend.Variable, curve EdCurve) *Point {
 
 	// first unpack the scalar
 	// TODO handle this properly (put the size in curve struct probably)
@@ -143,7 +143,7 @@ func (p *Point) ScalarMulNonFixedBase(cs *frontend.ConstraintSystem, p1 *Point,
 // curve: parameters of the Edwards curve
 // scal: scalar as a SNARK constraint
 // Standard left to right double and add
-func (p *Point) ScalarMulFixedBase(cs *frontend.ConstraintSystem, x, y interface{}, scalar frontend.Variable, curve EdCurve) *Point {
+func (p *Point) ScalarMulFixedBase(cs frontend.API, x, y interface{}, scalar frontend.Variable, curve EdCurve) *Point {
 
 	// first unpack the scalar
 	// TODO handle this properly (put the size in curve struct probably)
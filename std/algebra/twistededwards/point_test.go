@@ -29,7 +29,7 @@ type mustBeOnCurve struct {
 	P Point
 }
 
-func (circuit *mustBeOnCurve) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *mustBeOnCurve) Define(curveID ecc.ID, cs frontend.API) error {
 	// get edwards curve params
 	params, err := NewEdCurve(curveID)
 	if err != nil {
@@ -66,7 +66,7 @@ type add struct {
 	P Point
 }
 
-func (circuit *add) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *add) Define(curveID ecc.ID, cs frontend.API) error {
 
 	// get edwards curve params
 	params, err := NewEdCurve(curveID)
@@ -105,7 +105,7 @@ type addGeneric struct {
 	P1, P2 Point
 }
 
-func (circuit *addGeneric) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *addGeneric) Define(curveID ecc.ID, cs frontend.API) error {
 	// get edwards curve params
 	params, err := NewEdCurve(curveID)
 	if err != nil {
@@ -145,7 +145,7 @@ type double struct {
 	P Point
 }
 
-func (circuit *double) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *double) Define(curveID ecc.ID, cs frontend.API) error {
 	// get edwards curve params
 	params, err := NewEdCurve(curveID)
 	if err != nil {
@@ -179,7 +179,7 @@ type scalarMul struct {
 	P Point
 }
 
-func (circuit *scalarMul) Define(curveID ecc.ID, cs *frontend.ConstraintSystem) error {
+func (circuit *scalarMul) Define(curveID ecc.ID, cs frontend.API) error {
 
 	// get edwards curve params
 	params, err := NewEdCurve(curveID)
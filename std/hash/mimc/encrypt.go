@@ -28,11 +28,11 @@ import (
 	"github.com/consensys/gnark/frontend"
 )
 
-var encryptFuncs map[ecc.ID]func(*frontend.ConstraintSystem, MiMC, frontend.Variable, frontend.Variable) frontend.Variable
+var encryptFuncs map[ecc.ID]func(frontend.API, MiMC, frontend.Variable, frontend.Variable) frontend.Variable
 var newMimc map[ecc.ID]func(string) MiMC
 
 func init() {
-	encryptFuncs = make(map[ecc.ID]func(*frontend.ConstraintSystem, MiMC, frontend.Variable, frontend.Variable) frontend.Variable)
+	encryptFuncs = make(map[ecc.ID]func(frontend.API, MiMC, frontend.Variable, frontend.Variable) frontend.Variable)
 	encryptFuncs[ecc.BN254] = encryptBN254
 	encryptFuncs[ecc.BLS12_381] = encryptBLS381
 	encryptFuncs[ecc.BLS12_377] = encryptBLS377
@@ -100,7 +100,7 @@ func newMimcBW761(seed string) MiMC {
 // encryptions functions
 
 // encryptBn256 of a mimc run expressed as r1cs
-func encryptBN254(cs *frontend.ConstraintSystem, h MiMC, message, key frontend.Variable) frontend.Variable {
+func encryptBN254(cs frontend.API, h MiMC, message, key frontend.Variable) frontend.Variable {
 
 	res := message
 	// one := big.NewInt(1)
@@ -117,7 +117,7 @@ func encryptBN254(cs *frontend.ConstraintSystem, h MiMC, message, key frontend.V
 }
 
 // execution of a mimc run expressed as r1cs
-func encryptBLS381(cs *frontend.ConstraintSystem, h MiMC, message frontend.Variable, key frontend.Variable) frontend.Variable {
+func encryptBLS381(cs frontend.API, h MiMC, message frontend.Variable, key frontend.Variable) frontend.Variable {
 
 	res := message
 
@@ -133,7 +133,7 @@ func encryptBLS381(cs *frontend.ConstraintSystem, h MiMC, message frontend.Varia
 }
 
 // execution of a mimc run expressed as r1cs
-func encryptBW761(cs *frontend.ConstraintSystem, h MiMC, message frontend.Variable, key frontend.Variable) frontend.Variable {
+func encryptBW761(cs frontend.API, h MiMC, message frontend.Variable, key frontend.Variable) frontend.Variable {
 
 	res := message
 
@@ -150,7 +150,7 @@ func encryptBW761(cs *frontend.ConstraintSystem, h MiMC, message frontend.Variab
 }
 
 // encryptBLS377 of a mimc run expressed as r1cs
-func encryptBLS377(cs *frontend.ConstraintSystem, h MiMC, message frontend.Variable, key frontend.Variable) frontend.Variable {
+func encryptBLS377(cs frontend.API, h MiMC, message frontend.Variable, key frontend.Variable) frontend.Variable {
 	res := message
 	for i := 0; i < len(h.params); i++ {
 		tmp := cs.Add(res, h.params[i], key)
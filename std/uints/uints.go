@@ -0,0 +1,176 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package uints implements bounded unsigned integers (uint8, uint32, uint64...) operations
+// in a circuit: shifts, rotations, bitwise operations and modular addition.
+//
+// A Word holds the binary decomposition of the integer, so that chaining bitwise operations
+// doesn't require to decompose and recompose the integer each time (see NewWord and Word.Variable).
+package uints
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Widths of the usual unsigned integers
+const (
+	Uint8  = 8
+	Uint32 = 32
+	Uint64 = 64
+)
+
+// Word unsigned integer of len(Bits) bits, stored in little endian (Bits[0] is the lsb)
+type Word struct {
+	Bits []frontend.Variable
+}
+
+// NewWord decomposes v in width bits. The decomposition ensures v fits on width bits.
+func NewWord(cs frontend.API, v frontend.Variable, width int) Word {
+	return Word{Bits: cs.ToBinary(v, width)}
+}
+
+// ConstantWord returns the width bits word set to c
+func ConstantWord(cs frontend.API, c uint64, width int) Word {
+	res := Word{Bits: make([]frontend.Variable, width)}
+	for i := 0; i < width; i++ {
+		if i < 64 {
+			res.Bits[i] = cs.Constant((c >> i) & 1)
+		} else {
+			res.Bits[i] = cs.Constant(0)
+		}
+	}
+	return res
+}
+
+// Width returns the number of bits of w
+func (w Word) Width() int {
+	return len(w.Bits)
+}
+
+// Variable packs w in a Variable
+func (w Word) Variable(cs frontend.API) frontend.Variable {
+	return cs.FromBinary(w.Bits...)
+}
+
+// Lsh sets w to a << n, the bits shifted out are discarded (no constraint)
+func (w *Word) Lsh(cs frontend.API, a Word, n int) *Word {
+	res := make([]frontend.Variable, a.Width())
+	for i := 0; i < len(res); i++ {
+		if i < n {
+			res[i] = cs.Constant(0)
+		} else {
+			res[i] = a.Bits[i-n]
+		}
+	}
+	w.Bits = res
+	return w
+}
+
+// Rsh sets w to a >> n, the bits shifted out are discarded (no constraint)
+func (w *Word) Rsh(cs frontend.API, a Word, n int) *Word {
+	res := make([]frontend.Variable, a.Width())
+	for i := 0; i < len(res); i++ {
+		if i+n < len(res) {
+			res[i] = a.Bits[i+n]
+		} else {
+			res[i] = cs.Constant(0)
+		}
+	}
+	w.Bits = res
+	return w
+}
+
+// RotateLeft sets w to a rotated left by n bits, to rotate right call RotateLeft with a negative n
+// (same convention as math/bits) (no constraint)
+func (w *Word) RotateLeft(cs frontend.API, a Word, n int) *Word {
+	width := a.Width()
+	n %= width
+	if n < 0 {
+		n += width
+	}
+	res := make([]frontend.Variable, width)
+	for i := 0; i < width; i++ {
+		res[(i+n)%width] = a.Bits[i]
+	}
+	w.Bits = res
+	return w
+}
+
+// And sets w to a & b (one constraint per bit)
+func (w *Word) And(cs frontend.API, a, b Word) *Word {
+	mustHaveSameWidth(a, b)
+	res := make([]frontend.Variable, a.Width())
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.And(a.Bits[i], b.Bits[i])
+	}
+	w.Bits = res
+	return w
+}
+
+// Or sets w to a | b (one constraint per bit)
+func (w *Word) Or(cs frontend.API, a, b Word) *Word {
+	mustHaveSameWidth(a, b)
+	res := make([]frontend.Variable, a.Width())
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.Or(a.Bits[i], b.Bits[i])
+	}
+	w.Bits = res
+	return w
+}
+
+// Xor sets w to a ^ b (one constraint per bit)
+func (w *Word) Xor(cs frontend.API, a, b Word) *Word {
+	mustHaveSameWidth(a, b)
+	res := make([]frontend.Variable, a.Width())
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.Xor(a.Bits[i], b.Bits[i])
+	}
+	w.Bits = res
+	return w
+}
+
+// Not sets w to ^a (no constraint)
+func (w *Word) Not(cs frontend.API, a Word) *Word {
+	res := make([]frontend.Variable, a.Width())
+	for i := 0; i < len(res); i++ {
+		res[i] = cs.Sub(1, a.Bits[i])
+	}
+	w.Bits = res
+	return w
+}
+
+// Add sets w to a + b + others[0] + ... mod 2^width
+//
+// The sum is decomposed on width + log2(number of operands) bits, and the carry bits are discarded.
+func (w *Word) Add(cs frontend.API, a, b Word, others ...Word) *Word {
+	mustHaveSameWidth(a, b)
+	sum := cs.Add(a.Variable(cs), b.Variable(cs))
+	for i := 0; i < len(others); i++ {
+		mustHaveSameWidth(a, others[i])
+		sum = cs.Add(sum, others[i].Variable(cs))
+	}
+	nbCarryBits := bits.Len(uint(len(others) + 1))
+	w.Bits = cs.ToBinary(sum, a.Width()+nbCarryBits)[:a.Width()]
+	return w
+}
+
+func mustHaveSameWidth(a, b Word) {
+	if a.Width() != b.Width() {
+		panic("uints: operands must have the same width")
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uints
+
+import (
+	"math/bits"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/test"
+)
+
+type uint32Circuit struct {
+	X, Y                                         frontend.Variable
+	Lsh, Rsh, RotL, RotR, And, Or, Xor, Not, Add frontend.Variable `gnark:",public"`
+}
+
+func (circuit *uint32Circuit) Define(curveID ecc.ID, cs frontend.API) error {
+	x := NewWord(cs, circuit.X, Uint32)
+	y := NewWord(cs, circuit.Y, Uint32)
+
+	var w Word
+	cs.AssertIsEqual(w.Lsh(cs, x, 7).Variable(cs), circuit.Lsh)
+	cs.AssertIsEqual(w.Rsh(cs, x, 7).Variable(cs), circuit.Rsh)
+	cs.AssertIsEqual(w.RotateLeft(cs, x, 7).Variable(cs), circuit.RotL)
+	cs.AssertIsEqual(w.RotateLeft(cs, x, -7).Variable(cs), circuit.RotR)
+	cs.AssertIsEqual(w.And(cs, x, y).Variable(cs), circuit.And)
+	cs.AssertIsEqual(w.Or(cs, x, y).Variable(cs), circuit.Or)
+	cs.AssertIsEqual(w.Xor(cs, x, y).Variable(cs), circuit.Xor)
+	cs.AssertIsEqual(w.Not(cs, x).Variable(cs), circuit.Not)
+	cs.AssertIsEqual(w.Add(cs, x, y, x, ConstantWord(cs, 0xffffffff, Uint32)).Variable(cs), circuit.Add)
+
+	return nil
+}
+
+func uint32Witness(x, y uint32) *uint32Circuit {
+	var witness uint32Circuit
+	witness.X.Assign(uint64(x))
+	witness.Y.Assign(uint64(y))
+	witness.Lsh.Assign(uint64(x << 7))
+	witness.Rsh.Assign(uint64(x >> 7))
+	witness.RotL.Assign(uint64(bits.RotateLeft32(x, 7)))
+	witness.RotR.Assign(uint64(bits.RotateLeft32(x, -7)))
+	witness.And.Assign(uint64(x & y))
+	witness.Or.Assign(uint64(x | y))
+	witness.Xor.Assign(uint64(x ^ y))
+	witness.Not.Assign(uint64(^x))
+	witness.Add.Assign(uint64(x + y + x + 0xffffffff))
+	return &witness
+}
+
+func TestUint32(t *testing.T) {
+	assert := groth16.NewAssert(t)
+
+	var circuit uint32Circuit
+	r1cs, err := frontend.Compile(ecc.BN254, backend.GROTH16, &circuit)
+	assert.NoError(err)
+
+	good := uint32Witness(0xdeadbeef, 0x8badf00d)
+	assert.SolvingSucceeded(r1cs, good)
+	assert.NoError(test.IsSolved(&circuit, good, ecc.BN254))
+
+	bad := uint32Witness(0xdeadbeef, 0x8badf00d)
+	bad.Add = frontend.Variable{}
+	bad.Add.Assign(0)
+	assert.SolvingFailed(r1cs, bad)
+	assert.Error(test.IsSolved(&circuit, bad, ecc.BN254))
+
+	// X doesn't fit on 32 bits
+	overflow := uint32Witness(0, 0)
+	overflow.X = frontend.Variable{}
+	overflow.X.Assign(uint64(1) << 32)
+	assert.SolvingFailed(r1cs, overflow)
+	assert.Error(test.IsSolved(&circuit, overflow, ecc.BN254))
+}